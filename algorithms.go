@@ -12,6 +12,7 @@ package gdal
 */
 import "C"
 import (
+	"context"
 	"unsafe"
 )
 
@@ -27,9 +28,8 @@ func ComputeMedianCutPCT(
 	progress ProgressFunc,
 	data interface{},
 ) int {
-	arg := &goGDALProgressFuncProxyArgs{
-		progress, data,
-	}
+	pf, pa, release := progressProxy(progress, data)
+	defer release()
 
 	err := C.GDALComputeMedianCutPCT(
 		red.cval,
@@ -38,8 +38,8 @@ func ComputeMedianCutPCT(
 		nil,
 		C.int(colors),
 		ct.cval,
-		C.goGDALProgressFuncProxyB(),
-		unsafe.Pointer(arg),
+		pf,
+		pa,
 	)
 	return int(err)
 }
@@ -51,9 +51,8 @@ func DitherRGB2PCT(
 	progress ProgressFunc,
 	data interface{},
 ) int {
-	arg := &goGDALProgressFuncProxyArgs{
-		progress, data,
-	}
+	pf, pa, release := progressProxy(progress, data)
+	defer release()
 
 	err := C.GDALDitherRGB2PCT(
 		red.cval,
@@ -61,14 +60,15 @@ func DitherRGB2PCT(
 		blue.cval,
 		target.cval,
 		ct.cval,
-		C.goGDALProgressFuncProxyB(),
-		unsafe.Pointer(arg),
+		pf,
+		pa,
 	)
 	return int(err)
 }
 
 // Compute checksum for image region
 func (rb RasterBand) Checksum(xOff, yOff, xSize, ySize int) int {
+	rb.check()
 	sum := C.GDALChecksumImage(rb.cval, C.int(xOff), C.int(yOff), C.int(xSize), C.int(ySize))
 	return int(sum)
 }
@@ -80,9 +80,9 @@ func (src RasterBand) ComputeProximity(
 	progress ProgressFunc,
 	data interface{},
 ) error {
-	arg := &goGDALProgressFuncProxyArgs{
-		progress, data,
-	}
+	src.check()
+	pf, pa, release := progressProxy(progress, data)
+	defer release()
 
 	length := len(options)
 	opts := make([]*C.char, length+1)
@@ -92,13 +92,29 @@ func (src RasterBand) ComputeProximity(
 	}
 	opts[length] = (*C.char)(unsafe.Pointer(nil))
 
-	return C.GDALComputeProximity(
-		src.cval,
-		dest.cval,
-		(**C.char)(unsafe.Pointer(&opts[0])),
-		C.goGDALProgressFuncProxyB(),
-		unsafe.Pointer(arg),
-	).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALComputeProximity(
+			src.cval,
+			dest.cval,
+			(**C.char)(unsafe.Pointer(&opts[0])),
+			pf,
+			pa,
+		)
+	})
+}
+
+// Compute the proximity of all pixels in the image to a set of pixels in the
+// source image, aborting when ctx is done
+func (src RasterBand) ComputeProximityContext(
+	ctx context.Context,
+	dest RasterBand,
+	options []string,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	src.check()
+	err := src.ComputeProximity(dest, options, ProgressFromContext(ctx, progress), data)
+	return contextError(ctx, err)
 }
 
 // Fill selected raster regions by interpolation from the edges
@@ -110,9 +126,9 @@ func (src RasterBand) FillNoData(
 	progress ProgressFunc,
 	data interface{},
 ) error {
-	arg := &goGDALProgressFuncProxyArgs{
-		progress, data,
-	}
+	src.check()
+	pf, pa, release := progressProxy(progress, data)
+	defer release()
 
 	length := len(options)
 	opts := make([]*C.char, length+1)
@@ -122,16 +138,37 @@ func (src RasterBand) FillNoData(
 	}
 	opts[length] = (*C.char)(unsafe.Pointer(nil))
 
-	return C.GDALFillNodata(
-		src.cval,
-		mask.cval,
-		C.double(distance),
-		0,
-		C.int(iterations),
-		(**C.char)(unsafe.Pointer(&opts[0])),
-		C.goGDALProgressFuncProxyB(),
-		unsafe.Pointer(arg),
-	).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALFillNodata(
+			src.cval,
+			mask.cval,
+			C.double(distance),
+			0,
+			C.int(iterations),
+			(**C.char)(unsafe.Pointer(&opts[0])),
+			pf,
+			pa,
+		)
+	})
+}
+
+// Fill selected raster regions by interpolation from the edges, aborting
+// when ctx is done
+func (src RasterBand) FillNoDataContext(
+	ctx context.Context,
+	mask RasterBand,
+	distance float64,
+	iterations int,
+	options []string,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	src.check()
+	err := src.FillNoData(
+		mask, distance, iterations, options,
+		ProgressFromContext(ctx, progress), data,
+	)
+	return contextError(ctx, err)
 }
 
 // Create polygon coverage from raster data using an integer buffer
@@ -143,9 +180,9 @@ func (src RasterBand) Polygonize(
 	progress ProgressFunc,
 	data interface{},
 ) error {
-	arg := &goGDALProgressFuncProxyArgs{
-		progress, data,
-	}
+	src.check()
+	pf, pa, release := progressProxy(progress, data)
+	defer release()
 
 	length := len(options)
 	opts := make([]*C.char, length+1)
@@ -155,15 +192,36 @@ func (src RasterBand) Polygonize(
 	}
 	opts[length] = (*C.char)(unsafe.Pointer(nil))
 
-	return C.GDALPolygonize(
-		src.cval,
-		mask.cval,
-		layer.cval,
-		C.int(fieldIndex),
-		(**C.char)(unsafe.Pointer(&opts[0])),
-		C.goGDALProgressFuncProxyB(),
-		unsafe.Pointer(arg),
-	).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALPolygonize(
+			src.cval,
+			mask.cval,
+			layer.cval,
+			C.int(fieldIndex),
+			(**C.char)(unsafe.Pointer(&opts[0])),
+			pf,
+			pa,
+		)
+	})
+}
+
+// Create polygon coverage from raster data using an integer buffer,
+// aborting when ctx is done
+func (src RasterBand) PolygonizeContext(
+	ctx context.Context,
+	mask RasterBand,
+	layer Layer,
+	fieldIndex int,
+	options []string,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	src.check()
+	err := src.Polygonize(
+		mask, layer, fieldIndex, options,
+		ProgressFromContext(ctx, progress), data,
+	)
+	return contextError(ctx, err)
 }
 
 // Create polygon coverage from raster data using a floating point buffer
@@ -175,9 +233,9 @@ func (src RasterBand) FPolygonize(
 	progress ProgressFunc,
 	data interface{},
 ) error {
-	arg := &goGDALProgressFuncProxyArgs{
-		progress, data,
-	}
+	src.check()
+	pf, pa, release := progressProxy(progress, data)
+	defer release()
 
 	length := len(options)
 	opts := make([]*C.char, length+1)
@@ -187,15 +245,36 @@ func (src RasterBand) FPolygonize(
 	}
 	opts[length] = (*C.char)(unsafe.Pointer(nil))
 
-	return C.GDALFPolygonize(
-		src.cval,
-		mask.cval,
-		layer.cval,
-		C.int(fieldIndex),
-		(**C.char)(unsafe.Pointer(&opts[0])),
-		C.goGDALProgressFuncProxyB(),
-		unsafe.Pointer(arg),
-	).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALFPolygonize(
+			src.cval,
+			mask.cval,
+			layer.cval,
+			C.int(fieldIndex),
+			(**C.char)(unsafe.Pointer(&opts[0])),
+			pf,
+			pa,
+		)
+	})
+}
+
+// Create polygon coverage from raster data using a floating point buffer,
+// aborting when ctx is done
+func (src RasterBand) FPolygonizeContext(
+	ctx context.Context,
+	mask RasterBand,
+	layer Layer,
+	fieldIndex int,
+	options []string,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	src.check()
+	err := src.FPolygonize(
+		mask, layer, fieldIndex, options,
+		ProgressFromContext(ctx, progress), data,
+	)
+	return contextError(ctx, err)
 }
 
 // Removes small raster polygons
@@ -206,9 +285,9 @@ func (src RasterBand) SieveFilter(
 	progress ProgressFunc,
 	data interface{},
 ) error {
-	arg := &goGDALProgressFuncProxyArgs{
-		progress, data,
-	}
+	src.check()
+	pf, pa, release := progressProxy(progress, data)
+	defer release()
 
 	length := len(options)
 	opts := make([]*C.char, length+1)
@@ -218,16 +297,35 @@ func (src RasterBand) SieveFilter(
 	}
 	opts[length] = (*C.char)(unsafe.Pointer(nil))
 
-	return C.GDALSieveFilter(
-		src.cval,
-		mask.cval,
-		dest.cval,
-		C.int(threshold),
-		C.int(connectedness),
-		(**C.char)(unsafe.Pointer(&opts[0])),
-		C.goGDALProgressFuncProxyB(),
-		unsafe.Pointer(arg),
-	).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALSieveFilter(
+			src.cval,
+			mask.cval,
+			dest.cval,
+			C.int(threshold),
+			C.int(connectedness),
+			(**C.char)(unsafe.Pointer(&opts[0])),
+			pf,
+			pa,
+		)
+	})
+}
+
+// Removes small raster polygons, aborting when ctx is done
+func (src RasterBand) SieveFilterContext(
+	ctx context.Context,
+	mask, dest RasterBand,
+	threshold, connectedness int,
+	options []string,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	src.check()
+	err := src.SieveFilter(
+		mask, dest, threshold, connectedness, options,
+		ProgressFromContext(ctx, progress), data,
+	)
+	return contextError(ctx, err)
 }
 
 /* --------------------------------------------- */
@@ -281,12 +379,9 @@ func (src Dataset) ReprojectImage(
 	data interface{},
 	options WarpOptions,
 ) error {
-	var pf C.GDALProgressFunc
-	var pa unsafe.Pointer
-	if progress != nil {
-		pf = C.goGDALProgressFuncProxyB()
-		pa = unsafe.Pointer(&goGDALProgressFuncProxyArgs{progress, data})
-	}
+	src.check()
+	pf, pa, release := progressProxy(progress, data)
+	defer release()
 
 	var c_srcWKT, c_dstWKT *C.char
 	if srcProjWKT != "" {
@@ -298,18 +393,41 @@ func (src Dataset) ReprojectImage(
 		defer C.free(unsafe.Pointer(c_dstWKT))
 	}
 
-	return C.GDALReprojectImage(
-		src.cval,
-		c_srcWKT,
-		dst.cval,
-		c_dstWKT,
-		C.GDALResampleAlg(resampleAlg),
-		C.double(memLimit),
-		C.double(maxError),
-		pf,
-		pa,
-		options,
-	).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALReprojectImage(
+			src.cval,
+			c_srcWKT,
+			dst.cval,
+			c_dstWKT,
+			C.GDALResampleAlg(resampleAlg),
+			C.double(memLimit),
+			C.double(maxError),
+			pf,
+			pa,
+			options,
+		)
+	})
+}
+
+// Reproject image, aborting when ctx is done.  The returned error wraps
+// ctx.Err() if the reprojection was aborted.
+func (src Dataset) ReprojectImageContext(
+	ctx context.Context,
+	srcProjWKT string,
+	dst Dataset,
+	dstProjWKT string,
+	resampleAlg ResampleAlg,
+	memLimit, maxError float64,
+	progress ProgressFunc,
+	data interface{},
+	options WarpOptions,
+) error {
+	src.check()
+	err := src.ReprojectImage(
+		srcProjWKT, dst, dstProjWKT, resampleAlg, memLimit, maxError,
+		ProgressFromContext(ctx, progress), data, options,
+	)
+	return contextError(ctx, err)
 }
 
 //Unimplemented: CreateGenImgProjTransformer
@@ -0,0 +1,88 @@
+package gdal
+
+import (
+	"image"
+	"iter"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Block iteration.                                                */
+/* -------------------------------------------------------------------- */
+
+// Block is one natural block of a raster band.  Window is the region of
+// the band covered by the block, which is smaller than the natural block
+// size for blocks on the right and bottom edges, and Data holds its
+// Window.Dx() by Window.Dy() pixels in row major order.
+type Block[T Pixel] struct {
+	XBlock, YBlock int
+	Window         image.Rectangle
+	Data           []T
+}
+
+// Return the windows of the natural blocks of the band, row by row
+func blockWindows(band RasterBand) []image.Rectangle {
+	xSize, ySize := band.XSize(), band.YSize()
+	blockX, blockY := band.BlockSize()
+	if blockX <= 0 || blockY <= 0 {
+		blockX, blockY = xSize, 1
+	}
+
+	var windows []image.Rectangle
+	for y := 0; y < ySize; y += blockY {
+		for x := 0; x < xSize; x += blockX {
+			windows = append(windows, image.Rect(x, y, min(x+blockX, xSize), min(y+blockY, ySize)))
+		}
+	}
+	return windows
+}
+
+// Iterate over the natural blocks of the band, converting pixels to T.  If
+// access is Update, each block is written back to the band once the loop
+// body for it returns, so changes made to Data are kept.  The Data slice is
+// reused between blocks.
+//
+// A read or write error is yielded with an empty block and ends the
+// iteration.  When the loop body breaks out of the loop in Update mode, the
+// current block is still written back, but an error writing it cannot be
+// yielded once the loop has ended and is dropped; flush the band or close
+// its dataset to find out whether writing succeeded.
+func Blocks[T Pixel](band RasterBand, access Access) iter.Seq2[Block[T], error] {
+	return func(yield func(Block[T], error) bool) {
+		blockX, blockY := band.BlockSize()
+		if blockX <= 0 || blockY <= 0 {
+			blockX, blockY = band.XSize(), 1
+		}
+		buffer := make([]T, blockX*blockY)
+
+		for _, window := range blockWindows(band) {
+			w, h := window.Dx(), window.Dy()
+			block := Block[T]{
+				XBlock: window.Min.X / blockX,
+				YBlock: window.Min.Y / blockY,
+				Window: window,
+				Data:   buffer[:w*h],
+			}
+			err := RasterIO(band, Read, window.Min.X, window.Min.Y, w, h, block.Data, w, h, 0, 0)
+			if err != nil {
+				yield(Block[T]{}, err)
+				return
+			}
+
+			more := yield(block, nil)
+			if access == Update {
+				err := RasterIO(band, Write, window.Min.X, window.Min.Y, w, h, block.Data, w, h, 0, 0)
+				if err != nil {
+					// The loop has ended when !more, and yield must not be
+					// called again
+					if more {
+						yield(Block[T]{}, err)
+					}
+					return
+				}
+			}
+			if !more {
+				return
+			}
+		}
+	}
+}
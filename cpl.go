@@ -12,6 +12,13 @@ package gdal
 */
 import "C"
 import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"runtime/cgo"
+	"strings"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -33,3 +40,254 @@ func SetConfigOption(key, value string) {
 	defer C.free(unsafe.Pointer(v))
 	C.CPLSetConfigOption(k, v)
 }
+
+/* -------------------------------------------------------------------- */
+/*      Error handling.                                                 */
+/* -------------------------------------------------------------------- */
+
+// Class of an error reported through CPLError
+type ErrorClass int
+
+const (
+	CE_None    = ErrorClass(C.CE_None)
+	CE_Debug   = ErrorClass(C.CE_Debug)
+	CE_Warning = ErrorClass(C.CE_Warning)
+	CE_Failure = ErrorClass(C.CE_Failure)
+	CE_Fatal   = ErrorClass(C.CE_Fatal)
+)
+
+func (class ErrorClass) Name() string {
+	switch class {
+	case CE_None:
+		return "None"
+	case CE_Debug:
+		return "Debug"
+	case CE_Warning:
+		return "Warning"
+	case CE_Failure:
+		return "Failure"
+	case CE_Fatal:
+		return "Fatal"
+	}
+	return "Illegal"
+}
+
+// Error number reported through CPLError
+type ErrorNum int
+
+const (
+	CPLE_None            = ErrorNum(C.CPLE_None)
+	CPLE_AppDefined      = ErrorNum(C.CPLE_AppDefined)
+	CPLE_OutOfMemory     = ErrorNum(C.CPLE_OutOfMemory)
+	CPLE_FileIO          = ErrorNum(C.CPLE_FileIO)
+	CPLE_OpenFailed      = ErrorNum(C.CPLE_OpenFailed)
+	CPLE_IllegalArg      = ErrorNum(C.CPLE_IllegalArg)
+	CPLE_NotSupported    = ErrorNum(C.CPLE_NotSupported)
+	CPLE_AssertionFailed = ErrorNum(C.CPLE_AssertionFailed)
+	CPLE_NoWriteAccess   = ErrorNum(C.CPLE_NoWriteAccess)
+	CPLE_UserInterrupt   = ErrorNum(C.CPLE_UserInterrupt)
+	CPLE_ObjectNull      = ErrorNum(C.CPLE_ObjectNull)
+)
+
+// A single error or warning emitted through CPLError
+type ErrorMessage struct {
+	Class ErrorClass
+	Num   ErrorNum
+	Msg   string
+}
+
+// Error describes a failure reported by GDAL, carrying the error class,
+// error number and message that were current when the failure occurred.
+// For OGR failures Code holds the OGRERR_* value returned by the call.
+// Messages holds every error and warning emitted during the call, in order.
+type Error struct {
+	Class    ErrorClass
+	Num      ErrorNum
+	Msg      string
+	Code     OGRError
+	Messages []ErrorMessage
+}
+
+func (err *Error) Error() string {
+	msg := err.Msg
+	if err.Code != OGRERR_NONE {
+		if msg == "" {
+			return err.Code.Error()
+		}
+		msg = err.Code.Error() + ": " + msg
+	}
+	if msg == "" {
+		msg = "no message"
+	}
+	return fmt.Sprintf("%s Error %d: %s", err.Class.Name(), int(err.Num), msg)
+}
+
+// Is reports whether target is one of the sentinel errors matching this
+// error's class (ErrDebug, ErrWarning, ErrFailure, ErrFatal) or its OGR
+// error code.
+func (err *Error) Is(target error) bool {
+	if code, ok := target.(OGRError); ok {
+		return err.Code != OGRERR_NONE && err.Code == code
+	}
+	switch target {
+	case ErrDebug:
+		return err.Class == CE_Debug
+	case ErrWarning:
+		return err.Class == CE_Warning
+	case ErrFailure:
+		return err.Class == CE_Failure || err.Class == CE_Fatal
+	case ErrFatal:
+		return err.Class == CE_Fatal
+	}
+	return false
+}
+
+// Build an error of the given class from the last error registered by CPLError
+func lastError(class ErrorClass) *Error {
+	return &Error{
+		Class: class,
+		Num:   ErrorNum(C.CPLGetLastErrorNo()),
+		Msg:   C.GoString(C.CPLGetLastErrorMsg()),
+	}
+}
+
+// Build an error of the given class from the messages captured during a
+// call, using the last message of at least that class as the primary one
+func newError(class ErrorClass, messages []ErrorMessage) *Error {
+	err := &Error{Class: class, Messages: messages}
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Class >= class {
+			err.Num = messages[i].Num
+			err.Msg = messages[i].Msg
+			break
+		}
+	}
+	return err
+}
+
+// Build a failure from the messages captured during a call that reported
+// failure through its return value rather than an error code
+func failure(messages []ErrorMessage, format string, args ...interface{}) *Error {
+	err := newError(CE_Failure, messages)
+	if err.Msg == "" {
+		err.Num = CPLE_AppDefined
+		err.Msg = fmt.Sprintf(format, args...)
+	}
+	return err
+}
+
+// Collects the messages emitted through CPLError during a single call
+type errorCapture struct {
+	messages []ErrorMessage
+}
+
+//export goCPLErrorHandlerProxyA
+func goCPLErrorHandlerProxyA(errClass, errNum C.int, message *C.char, handle C.uintptr_t) {
+	capture := cgo.Handle(handle).Value().(*errorCapture)
+	class := ErrorClass(errClass)
+	if class == CE_Debug || class == CE_Warning {
+		emitMessage(class, ErrorNum(errNum), message)
+	}
+	if class == CE_Debug {
+		return
+	}
+	capture.messages = append(capture.messages, ErrorMessage{
+		Class: class,
+		Num:   ErrorNum(errNum),
+		Msg:   C.GoString(message),
+	})
+}
+
+// Run fn with a CPL error handler pushed for its duration, returning the
+// errors and warnings emitted during the call.  Debug and warning messages
+// are also passed on to the logger, or written to stderr by default.  CPL
+// error state is kept per thread, so the goroutine is locked to its thread
+// until fn returns.
+func captureErrors(fn func()) []ErrorMessage {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	capture := &errorCapture{}
+	handle := cgo.NewHandle(capture)
+	defer handle.Delete()
+
+	C.goCPLPushErrorHandler(C.uintptr_t(handle))
+	defer C.CPLPopErrorHandler()
+
+	fn()
+	return capture.messages
+}
+
+/* -------------------------------------------------------------------- */
+/*      Logging.                                                        */
+/* -------------------------------------------------------------------- */
+
+var logger atomic.Pointer[slog.Logger]
+
+// Route GDAL debug, warning and error output to logger instead of stderr.
+// Records carry the CPL error class and number as attributes, and debug
+// records their category.  Errors emitted during a call that reports them
+// through its returned error are not logged.  Pass nil to restore the
+// default handler.
+func SetLogHandler(l *slog.Logger) {
+	logger.Store(l)
+	C.goCPLSetLogHandler(BoolToCInt(l != nil))
+}
+
+// Enable CPLDebug output for the given categories, or for all categories
+// if none are given
+func EnableDebug(categories ...string) {
+	value := "ON"
+	if len(categories) > 0 {
+		value = strings.Join(categories, ",")
+	}
+	SetConfigOption("CPL_DEBUG", value)
+}
+
+// Disable CPLDebug output
+func DisableDebug() {
+	SetConfigOption("CPL_DEBUG", "OFF")
+}
+
+//export goCPLLogHandlerProxyA
+func goCPLLogHandlerProxyA(errClass, errNum C.int, message *C.char) {
+	logMessage(ErrorClass(errClass), ErrorNum(errNum), C.GoString(message))
+}
+
+// Send a CPL message to the installed logger, or to the default CPL
+// handler writing to stderr if none is installed
+func emitMessage(class ErrorClass, num ErrorNum, message *C.char) {
+	if logger.Load() == nil {
+		C.CPLDefaultErrorHandler(C.CPLErr(class), C.CPLErrorNum(num), message)
+		return
+	}
+	logMessage(class, num, C.GoString(message))
+}
+
+// Send a CPL message to the installed logger, if any
+func logMessage(class ErrorClass, num ErrorNum, msg string) {
+	l := logger.Load()
+	if l == nil {
+		return
+	}
+
+	level := slog.LevelError
+	switch class {
+	case CE_Debug:
+		level = slog.LevelDebug
+	case CE_Warning:
+		level = slog.LevelWarn
+	}
+
+	attrs := []slog.Attr{
+		slog.String("class", class.Name()),
+		slog.Int("num", int(num)),
+	}
+	if class == CE_Debug {
+		if category, text, ok := strings.Cut(msg, ": "); ok {
+			attrs = append(attrs, slog.String("category", category))
+			msg = text
+		}
+	}
+	l.LogAttrs(context.Background(), level, msg, attrs...)
+}
@@ -9,6 +9,10 @@ The documentation is fairly limited, but the functionality fairly closely matche
 
 This wrapper has most recently been tested on Windows7, using the MinGW32_x64 compiler and GDAL version 1.11.
 
+Errors
+
+Failing calls return an *Error carrying the CPL error class, number and messages raised by GDAL during the call.  Driver.Create and Driver.CreateCopy return (Dataset, error) rather than a bare Dataset, so callers written against earlier versions of this package need to handle the additional return value.
+
 Usage
 
 A simple program to create a georeferenced blank 256x256 GeoTIFF:
@@ -35,7 +39,11 @@ A simple program to create a georeferenced blank 256x256 GeoTIFF:
 			return
 		}
 
-		dataset := driver.Create(filename, 256, 256, 1, gdal.Byte, nil)
+		dataset, err := driver.Create(filename, 256, 256, 1, gdal.Byte, nil)
+		if err != nil {
+			fmt.Println(err.Error())
+			return
+		}
 		defer dataset.Close()
 
 		spatialRef := gdal.CreateSpatialReference("")
@@ -0,0 +1,168 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  CFLAGS: -I/usr/include/gdal
+#cgo linux  LDFLAGS: -lgdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"encoding/xml"
+	"strings"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Driver metadata.                                                */
+/* -------------------------------------------------------------------- */
+
+// Description of a creation option accepted by a driver
+type CreationOption struct {
+	Name        string   `xml:"name,attr"`
+	Type        string   `xml:"type,attr"`
+	Description string   `xml:"description,attr"`
+	Default     string   `xml:"default,attr"`
+	Min         string   `xml:"min,attr"`
+	Max         string   `xml:"max,attr"`
+	Values      []string `xml:"Value"`
+}
+
+// Fetch the creation options accepted by the driver, parsed from its
+// DMD_CREATIONOPTIONLIST metadata item
+func (driver Driver) CreationOptions() ([]CreationOption, error) {
+	list := driver.MetadataItem(DMD_CREATIONOPTIONLIST, "")
+	if list == "" {
+		return nil, nil
+	}
+	var parsed struct {
+		Options []CreationOption `xml:"Option"`
+	}
+	if err := xml.Unmarshal([]byte(list), &parsed); err != nil {
+		return nil, err
+	}
+	for i := range parsed.Options {
+		for j, value := range parsed.Options[i].Values {
+			parsed.Options[i].Values[j] = strings.TrimSpace(value)
+		}
+	}
+	return parsed.Options, nil
+}
+
+// Capabilities advertised by a driver
+type DriverCapabilities struct {
+	Create     bool
+	CreateCopy bool
+	VirtualIO  bool
+	Raster     bool
+	Vector     bool
+}
+
+// Fetch the capabilities of the driver
+func (driver Driver) Capabilities() DriverCapabilities {
+	has := func(capability string) bool {
+		return driver.MetadataItem(capability, "") == "YES"
+	}
+	return DriverCapabilities{
+		Create:     has(DCAP_CREATE),
+		CreateCopy: has(DCAP_CREATECOPY),
+		VirtualIO:  has(DCAP_VIRTUALIO),
+		Raster:     has(DCAP_RASTER),
+		Vector:     has(DCAP_VECTOR),
+	}
+}
+
+// Fetch the data types the driver can create bands of
+func (driver Driver) CreationDataTypes() []DataType {
+	var dataTypes []DataType
+	for _, name := range strings.Fields(driver.MetadataItem(DMD_CREATIONDATATYPES, "")) {
+		cName := C.CString(name)
+		dataType := DataType(C.GDALGetDataTypeByName(cName))
+		C.free(unsafe.Pointer(cName))
+		if dataType != Unknown {
+			dataTypes = append(dataTypes, dataType)
+		}
+	}
+	return dataTypes
+}
+
+// Fetch the file extensions used by the driver, without leading dots
+func (driver Driver) Extensions() []string {
+	extensions := strings.Fields(driver.MetadataItem(DMD_EXTENSIONS, ""))
+	if len(extensions) == 0 {
+		extensions = strings.Fields(driver.MetadataItem(DMD_EXTENSION, ""))
+	}
+	return extensions
+}
+
+// Check creation options against the options the driver accepts,
+// returning an error describing every problem found
+func (driver Driver) ValidateCreationOptions(options []string) error {
+	cOptions, free := cStringList(options)
+	defer free()
+
+	var valid C.int
+	messages := captureErrors(func() {
+		valid = C.GDALValidateCreationOptions(driver.cval, cOptions)
+	})
+	if valid != 0 {
+		return nil
+	}
+
+	var problems []string
+	for _, message := range messages {
+		if message.Class >= CE_Warning {
+			problems = append(problems, message.Msg)
+		}
+	}
+	if len(problems) == 0 {
+		problems = append(problems, "invalid creation options for driver "+driver.ShortName())
+	}
+	return &Error{
+		Class:    CE_Failure,
+		Num:      CPLE_IllegalArg,
+		Msg:      strings.Join(problems, "; "),
+		Messages: messages,
+	}
+}
+
+// Validate creation options before creating a dataset, unless disabled
+// with the GDAL_VALIDATE_CREATION_OPTIONS configuration option
+func (driver Driver) validateCreationOptions(options []string) error {
+	if len(options) == 0 {
+		return nil
+	}
+	key := C.CString("GDAL_VALIDATE_CREATION_OPTIONS")
+	defer C.free(unsafe.Pointer(key))
+	yes := C.CString("YES")
+	defer C.free(unsafe.Pointer(yes))
+	if C.CPLTestBool(C.CPLGetConfigOption(key, yes)) == 0 {
+		return nil
+	}
+	return driver.ValidateCreationOptions(options)
+}
+
+// Run fn with GDAL's own creation option validation disabled on the
+// calling thread.  Create and CreateCopy validate options beforehand unless
+// validation is disabled, in which case GDAL skips it too, so GDAL would
+// only report the same problems a second time.  fn must run within
+// captureErrors, which keeps the goroutine on its thread.
+func withoutCreationOptionValidation(fn func()) {
+	key := C.CString("GDAL_VALIDATE_CREATION_OPTIONS")
+	defer C.free(unsafe.Pointer(key))
+	no := C.CString("NO")
+	defer C.free(unsafe.Pointer(no))
+
+	var previous *C.char
+	if value := C.CPLGetThreadLocalConfigOption(key, nil); value != nil {
+		previous = C.CPLStrdup(value)
+		defer C.VSIFree(unsafe.Pointer(previous))
+	}
+	C.CPLSetThreadLocalConfigOption(key, no)
+	defer C.CPLSetThreadLocalConfigOption(key, previous)
+	fn()
+}
@@ -43,7 +43,11 @@ func main() {
 		return
 	}
 	fmt.Printf("Creating dataset\n")
-	dataset := driver.Create(filename, 256, 256, 1, gdal.Byte, nil)
+	dataset, err := driver.Create(filename, 256, 256, 1, gdal.Byte, nil)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
 	defer dataset.Close()
 
 	fmt.Printf("Creating projection\n")
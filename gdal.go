@@ -13,8 +13,13 @@ package gdal
 */
 import "C"
 import (
+	"context"
 	"errors"
 	"fmt"
+	"image"
+	"runtime"
+	"runtime/cgo"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -44,39 +49,68 @@ var (
 	ErrIllegal = errors.New("Illegal Error")
 )
 
-// Error handling.  The following is bare-bones, and needs to be replaced with something more useful.
+// Convert a CPLErr return value into an error carrying the last CPL error
+// number and message
 func (err _Ctype_CPLErr) Err() error {
-	switch err {
-	case 0:
+	switch class := ErrorClass(err); class {
+	case CE_None:
 		return nil
-	case 1:
-		return ErrDebug
-	case 2:
-		return ErrWarning
-	case 3:
-		return ErrFailure
-	case 4:
-		return ErrFailure
+	case CE_Debug, CE_Warning, CE_Failure, CE_Fatal:
+		return lastError(class)
 	}
 	return ErrIllegal
 }
 
+// Convert an OGRErr return value into an error carrying the OGR error code
+// and the last CPL error message
 func (err _Ctype_OGRErr) Err() error {
-	switch err {
-	case 0:
+	code := OGRError(err)
+	if code == OGRERR_NONE {
 		return nil
-	case 1:
-		return ErrDebug
-	case 2:
-		return ErrWarning
-	case 3:
-		return ErrFailure
-	case 4:
-		return ErrFailure
+	}
+	e := lastError(CE_Failure)
+	e.Code = code
+	return e
+}
+
+// Convert a CPLErr return value into an error built from the messages
+// captured during the call
+func cplError(err C.CPLErr, messages []ErrorMessage) error {
+	switch class := ErrorClass(err); class {
+	case CE_None:
+		return nil
+	case CE_Debug, CE_Warning, CE_Failure, CE_Fatal:
+		return newError(class, messages)
 	}
 	return ErrIllegal
 }
 
+// Convert an OGRErr return value into an error built from the messages
+// captured during the call
+func ogrError(err C.OGRErr, messages []ErrorMessage) error {
+	code := OGRError(err)
+	if code == OGRERR_NONE {
+		return nil
+	}
+	e := newError(CE_Failure, messages)
+	e.Code = code
+	return e
+}
+
+// Run a GDAL function returning CPLErr with per-call error capture
+func cplCall(fn func() C.CPLErr) error {
+	var err C.CPLErr
+	messages := captureErrors(func() { err = fn() })
+	return cplError(err, messages)
+}
+
+// Run an OGR function returning OGRErr with per-call error capture
+func ogrCall(fn func() C.OGRErr) error {
+	var err C.OGRErr
+	messages := captureErrors(func() { err = fn() })
+	return ogrError(err, messages)
+}
+
 // Pixel data types
 type DataType int
 
@@ -114,7 +148,7 @@ func (dataType DataType) Union(dataTypeB DataType) DataType {
 	)
 }
 
-//Safe array conversion
+// Safe array conversion
 func IntSliceToCInt(data []int) []C.int {
 	sliceSz := len(data)
 	result := make([]C.int, sliceSz)
@@ -124,7 +158,7 @@ func IntSliceToCInt(data []int) []C.int {
 	return result
 }
 
-//Safe array conversion
+// Safe array conversion
 func CIntSliceToInt(data []C.GUIntBig) []uint64 {
 	sliceSz := len(data)
 	result := make([]uint64, sliceSz)
@@ -243,10 +277,12 @@ type MajorObject struct {
 
 type Dataset struct {
 	cval C.GDALDatasetH
+	own  *owner
 }
 
 type RasterBand struct {
-	cval C.GDALRasterBandH
+	cval   C.GDALRasterBandH
+	parent *owner
 }
 
 type Driver struct {
@@ -255,14 +291,18 @@ type Driver struct {
 
 type ColorTable struct {
 	cval C.GDALColorTableH
+	own  *owner
 }
 
 type RasterAttributeTable struct {
 	cval C.GDALRasterAttributeTableH
+	own  *owner
 }
 
 type AsyncReader struct {
-	cval C.GDALAsyncReaderH
+	cval   C.GDALAsyncReaderH
+	own    *owner
+	parent *owner
 }
 
 type ColorEntry struct {
@@ -291,21 +331,103 @@ func TermProgress(complete float64, message string, data interface{}) int {
 	return int(retval)
 }
 
-func ScaledProgress(complete float64, message string, data interface{}) int {
-	msg := C.CString(message)
-	defer C.free(unsafe.Pointer(msg))
+// Return a progress function mapping the 0..1 range of an operation onto
+// min..max of progress, for reporting one step of a larger operation.  The
+// progress argument is passed through to progress unchanged.  Scaled
+// progress functions may be nested, and progress may be nil.
+func ScaleProgress(min, max float64, progress ProgressFunc) ProgressFunc {
+	return func(complete float64, message string, data interface{}) int {
+		if progress == nil {
+			return 1
+		}
+		return progress(min+complete*(max-min), message, data)
+	}
+}
 
-	retval := C.GDALScaledProgress(C.double(complete), msg, unsafe.Pointer(nil))
-	return int(retval)
+type scaledProgress struct {
+	progress ProgressFunc
+	data     interface{}
 }
 
-func CreateScaledProgress(min, max float64, progress ProgressFunc, data unsafe.Pointer) unsafe.Pointer {
-	panic("not implemented!")
-	return nil
+// Progress function forwarding to the progress function wrapped by
+// CreateScaledProgress.  data must be the value returned by
+// CreateScaledProgress; ScaledProgress panics on any other value.  New code
+// should use ScaleProgress.
+func ScaledProgress(complete float64, message string, data interface{}) int {
+	scaled, ok := data.(*scaledProgress)
+	if !ok {
+		panic(fmt.Sprintf("gdal: ScaledProgress called with %T rather than the result of CreateScaledProgress", data))
+	}
+	return scaled.progress(complete, message, scaled.data)
+}
+
+// Create a progress argument mapping the 0..1 range of an operation onto
+// min..max of progress, to be passed together with ScaledProgress to any
+// function accepting a ProgressFunc.  Scaled progress may be nested by
+// wrapping ScaledProgress itself.
+func CreateScaledProgress(min, max float64, progress ProgressFunc, data interface{}) interface{} {
+	return &scaledProgress{ScaleProgress(min, max, progress), data}
+}
+
+// Release a scaled progress argument.  Scaled progress is garbage collected,
+// so this is a no-op kept for symmetry with the C API.
+//
+// Deprecated: scaled progress needs no release; this function does nothing.
+func DestroyScaledProgress(data interface{}) {
+}
+
+// A progress update reported through a channel
+type ProgressEvent struct {
+	Complete float64
+	Message  string
+}
+
+// Return a progress function sending each update to ch.  Updates are
+// dropped rather than blocking the operation when ch is not ready, so ch
+// should be buffered.  The first update reporting completion is delivered
+// unless ctx is done first, in which case the operation is aborted; later
+// ones are dropped like intermediate updates.
+func ChannelProgress(ctx context.Context, ch chan<- ProgressEvent) ProgressFunc {
+	var delivered atomic.Bool
+	return func(complete float64, message string, data interface{}) int {
+		event := ProgressEvent{complete, message}
+		if complete >= 1 && !delivered.Swap(true) {
+			select {
+			case ch <- event:
+				return 1
+			case <-ctx.Done():
+				return 0
+			}
+		}
+		select {
+		case ch <- event:
+		default:
+		}
+		return 1
+	}
 }
 
-func DestroyScaledProgress(data unsafe.Pointer) {
-	C.GDALDestroyScaledProgress(data)
+// Adapt progress to abort the operation it is passed to once ctx is done.
+// progress may be nil.
+func ProgressFromContext(ctx context.Context, progress ProgressFunc) ProgressFunc {
+	return func(complete float64, message string, data interface{}) int {
+		if ctx.Err() != nil {
+			return 0
+		}
+		if progress == nil {
+			return 1
+		}
+		return progress(complete, message, data)
+	}
+}
+
+// Wrap the error of an operation run with ProgressFromContext in ctx.Err()
+// if the context is done
+func contextError(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("%w: %w", ctx.Err(), err)
+	}
+	return err
 }
 
 // -----------------------------------------------------------------------
@@ -315,12 +437,25 @@ type goGDALProgressFuncProxyArgs struct {
 	data          interface{}
 }
 
+// Register progress for the duration of a single GDAL call, returning the
+// C callback and argument to pass to GDAL and a function releasing them
+// once the call has returned.  The argument is a cgo.Handle rather than a
+// Go pointer, so progress may be any closure.  A nil progress yields a nil
+// callback.
+func progressProxy(progress ProgressFunc, data interface{}) (C.GDALProgressFunc, unsafe.Pointer, func()) {
+	if progress == nil {
+		return nil, nil, func() {}
+	}
+	handle := cgo.NewHandle(&goGDALProgressFuncProxyArgs{progress, data})
+	return C.goGDALProgressFuncProxyB(), C.goGDALProgressArg(C.uintptr_t(handle)), handle.Delete
+}
+
 //export goGDALProgressFuncProxyA
-func goGDALProgressFuncProxyA(complete C.double, message *C.char, data unsafe.Pointer) int {
-	arg := (*goGDALProgressFuncProxyArgs)(data)
-	return arg.progresssFunc(
+func goGDALProgressFuncProxyA(complete C.double, message *C.char, handle C.uintptr_t) C.int {
+	arg := cgo.Handle(handle).Value().(*goGDALProgressFuncProxyArgs)
+	return C.int(arg.progresssFunc(
 		float64(complete), C.GoString(message), arg.data,
-	)
+	))
 }
 
 /* ==================================================================== */
@@ -332,12 +467,15 @@ const (
 	DMD_HELPTOPIC          = string(C.GDAL_DMD_HELPTOPIC)
 	DMD_MIMETYPE           = string(C.GDAL_DMD_MIMETYPE)
 	DMD_EXTENSION          = string(C.GDAL_DMD_EXTENSION)
+	DMD_EXTENSIONS         = string(C.GDAL_DMD_EXTENSIONS)
 	DMD_CREATIONOPTIONLIST = string(C.GDAL_DMD_CREATIONOPTIONLIST)
 	DMD_CREATIONDATATYPES  = string(C.GDAL_DMD_CREATIONDATATYPES)
 
 	DCAP_CREATE     = string(C.GDAL_DCAP_CREATE)
 	DCAP_CREATECOPY = string(C.GDAL_DCAP_CREATECOPY)
 	DCAP_VIRTUALIO  = string(C.GDAL_DCAP_VIRTUALIO)
+	DCAP_RASTER     = string(C.GDAL_DCAP_RASTER)
+	DCAP_VECTOR     = string(C.GDAL_DCAP_VECTOR)
 )
 
 // Create a new dataset with this driver.
@@ -346,7 +484,10 @@ func (driver Driver) Create(
 	xSize, ySize, bands int,
 	dataType DataType,
 	options []string,
-) Dataset {
+) (Dataset, error) {
+	if err := driver.validateCreationOptions(options); err != nil {
+		return Dataset{}, err
+	}
 	name := C.CString(filename)
 	defer C.free(unsafe.Pointer(name))
 
@@ -358,17 +499,28 @@ func (driver Driver) Create(
 	}
 	opts[length] = (*C.char)(unsafe.Pointer(nil))
 
-	h := C.GDALCreate(
-		driver.cval,
-		name,
-		C.int(xSize), C.int(ySize), C.int(bands),
-		C.GDALDataType(dataType),
-		(**C.char)(unsafe.Pointer(&opts[0])),
-	)
-	return Dataset{h}
+	var h C.GDALDatasetH
+	messages := captureErrors(func() {
+		withoutCreationOptionValidation(func() {
+			h = C.GDALCreate(
+				driver.cval,
+				name,
+				C.int(xSize), C.int(ySize), C.int(bands),
+				C.GDALDataType(dataType),
+				(**C.char)(unsafe.Pointer(&opts[0])),
+			)
+		})
+	})
+	if h == nil {
+		return Dataset{cval: nil}, failure(messages, "Error: dataset '%s' create error", filename)
+	}
+	return ownCreatedDataset(h, messages), nil
 }
 
-// Create a copy of a dataset
+// Create a copy of a dataset.  Warnings GDAL emits along a successful copy
+// are passed on to the logger installed with SetLogHandler, or written to
+// stderr by default, and are available from the Warnings method of the
+// returned dataset.
 func (driver Driver) CreateCopy(
 	filename string,
 	sourceDataset Dataset,
@@ -376,7 +528,10 @@ func (driver Driver) CreateCopy(
 	options []string,
 	progress ProgressFunc,
 	data interface{},
-) Dataset {
+) (Dataset, error) {
+	if err := driver.validateCreationOptions(options); err != nil {
+		return Dataset{}, err
+	}
 	name := C.CString(filename)
 	defer C.free(unsafe.Pointer(name))
 
@@ -388,31 +543,45 @@ func (driver Driver) CreateCopy(
 	}
 	opts[length] = (*C.char)(unsafe.Pointer(nil))
 
-	var h C.GDALDatasetH
+	pf, pa, release := progressProxy(progress, data)
+	defer release()
 
-	if progress == nil {
-		h = C.GDALCreateCopy(
-			driver.cval, name,
-			sourceDataset.cval,
-			C.int(strict),
-			(**C.char)(unsafe.Pointer(&opts[0])),
-			nil,
-			nil,
-		)
-	} else {
-		arg := &goGDALProgressFuncProxyArgs{
-			progress, data,
-		}
-		h = C.GDALCreateCopy(
-			driver.cval, name,
-			sourceDataset.cval,
-			C.int(strict), (**C.char)(unsafe.Pointer(&opts[0])),
-			C.goGDALProgressFuncProxyB(),
-			unsafe.Pointer(arg),
-		)
+	var h C.GDALDatasetH
+	messages := captureErrors(func() {
+		withoutCreationOptionValidation(func() {
+			h = C.GDALCreateCopy(
+				driver.cval, name,
+				sourceDataset.cval,
+				C.int(strict), (**C.char)(unsafe.Pointer(&opts[0])),
+				pf,
+				pa,
+			)
+		})
+	})
+	// Keep the source from being finalized, closing it, during the copy
+	runtime.KeepAlive(sourceDataset.own)
+	if h == nil {
+		return Dataset{cval: nil}, failure(messages, "Error: dataset '%s' copy error", filename)
 	}
+	return ownCreatedDataset(h, messages), nil
+}
 
-	return Dataset{h}
+// Create a copy of a dataset, aborting the copy when ctx is done.  The
+// returned error wraps ctx.Err() if the copy was aborted.
+func (driver Driver) CreateCopyContext(
+	ctx context.Context,
+	filename string,
+	sourceDataset Dataset,
+	strict int,
+	options []string,
+	progress ProgressFunc,
+	data interface{},
+) (Dataset, error) {
+	ds, err := driver.CreateCopy(
+		filename, sourceDataset, strict, options,
+		ProgressFromContext(ctx, progress), data,
+	)
+	return ds, contextError(ctx, err)
 }
 
 // Return the driver needed to access the provided dataset name.
@@ -437,11 +606,92 @@ func Open(filename string, access Access) (Dataset, error) {
 	cFilename := C.CString(filename)
 	defer C.free(unsafe.Pointer(cFilename))
 
-	dataset := C.GDALOpen(cFilename, C.GDALAccess(access))
+	var dataset C.GDALDatasetH
+	messages := captureErrors(func() {
+		dataset = C.GDALOpen(cFilename, C.GDALAccess(access))
+	})
 	if dataset == nil {
-		return Dataset{nil}, fmt.Errorf("Error: dataset '%s' open error", filename)
+		return Dataset{cval: nil}, failure(messages, "Error: dataset '%s' open error", filename)
+	}
+	return ownDataset(dataset), nil
+}
+
+// Options for OpenEx
+type OpenOptions struct {
+	// Allow raster and/or vector datasets; if neither is set, both are
+	Raster bool
+	Vector bool
+	// Open for update rather than read-only access
+	Update bool
+	// Share the dataset with other callers opening it in shared mode
+	Shared bool
+	// Emit an error naming the reason when the dataset cannot be opened
+	Verbose bool
+	// Short names of the drivers allowed to open the dataset, or nil for
+	// all drivers
+	AllowedDrivers []string
+	// Driver specific NAME=VALUE open options
+	OpenOptions []string
+	// Files next to the dataset, saving GDAL from listing the directory, or
+	// nil to let GDAL list it
+	SiblingFiles []string
+}
+
+// Convert a string list to a NULL terminated C array, returning nil for a
+// nil list and a function freeing the strings
+func cStringList(list []string) (**C.char, func()) {
+	if list == nil {
+		return nil, func() {}
+	}
+	cList := make([]*C.char, len(list)+1)
+	for i, s := range list {
+		cList[i] = C.CString(s)
+	}
+	return (**C.char)(unsafe.Pointer(&cList[0])), func() {
+		for _, s := range cList {
+			C.free(unsafe.Pointer(s))
+		}
 	}
-	return Dataset{dataset}, nil
+}
+
+// Open a raster and/or vector dataset.  Datasets opened with Vector set
+// expose their layers through LayerCount, LayerByIndex and DataSource.
+func OpenEx(filename string, options OpenOptions) (Dataset, error) {
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	var flags C.uint
+	if options.Raster {
+		flags |= C.GDAL_OF_RASTER
+	}
+	if options.Vector {
+		flags |= C.GDAL_OF_VECTOR
+	}
+	if options.Update {
+		flags |= C.GDAL_OF_UPDATE
+	}
+	if options.Shared {
+		flags |= C.GDAL_OF_SHARED
+	}
+	if options.Verbose {
+		flags |= C.GDAL_OF_VERBOSE_ERROR
+	}
+
+	drivers, freeDrivers := cStringList(options.AllowedDrivers)
+	defer freeDrivers()
+	openOptions, freeOpenOptions := cStringList(options.OpenOptions)
+	defer freeOpenOptions()
+	siblings, freeSiblings := cStringList(options.SiblingFiles)
+	defer freeSiblings()
+
+	var dataset C.GDALDatasetH
+	messages := captureErrors(func() {
+		dataset = C.GDALOpenEx(cFilename, flags, drivers, openOptions, siblings)
+	})
+	if dataset == nil {
+		return Dataset{cval: nil}, failure(messages, "Error: dataset '%s' open error", filename)
+	}
+	return ownDataset(dataset), nil
 }
 
 // Open a shared existing dataset
@@ -450,7 +700,10 @@ func OpenShared(filename string, access Access) Dataset {
 	defer C.free(unsafe.Pointer(cFilename))
 
 	dataset := C.GDALOpenShared(cFilename, C.GDALAccess(access))
-	return Dataset{dataset}
+	if dataset == nil {
+		return Dataset{}
+	}
+	return ownDataset(dataset)
 }
 
 // Unimplemented: DumpOpenDatasets
@@ -505,7 +758,9 @@ func (driver Driver) DeleteDataset(name string) error {
 	cDriver := driver.cval
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	return C.GDALDeleteDataset(cDriver, cName).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALDeleteDataset(cDriver, cName)
+	})
 }
 
 // Rename named dataset
@@ -515,7 +770,9 @@ func (driver Driver) RenameDataset(newName, oldName string) error {
 	defer C.free(unsafe.Pointer(cNewName))
 	cOldName := C.CString(oldName)
 	defer C.free(unsafe.Pointer(cOldName))
-	return C.GDALRenameDataset(cDriver, cNewName, cOldName).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALRenameDataset(cDriver, cNewName, cOldName)
+	})
 }
 
 // Copy all files associated with the named dataset
@@ -525,7 +782,9 @@ func (driver Driver) CopyDatasetFiles(newName, oldName string) error {
 	defer C.free(unsafe.Pointer(cNewName))
 	cOldName := C.CString(oldName)
 	defer C.free(unsafe.Pointer(cOldName))
-	return C.GDALCopyDatasetFiles(cDriver, cNewName, cOldName).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALCopyDatasetFiles(cDriver, cNewName, cOldName)
+	})
 }
 
 // Get the short name associated with this driver
@@ -540,14 +799,70 @@ func (driver Driver) LongName() string {
 	return C.GoString(C.GDALGetDriverLongName(cDriver))
 }
 
+// Fetch a single metadata item of the driver, such as DCAP_CREATE
+func (driver Driver) MetadataItem(name, domain string) string {
+	return metadataItem(unsafe.Pointer(driver.cval), name, domain)
+}
+
 /* ==================================================================== */
 /*      GDAL_GCP                                                        */
 /* ==================================================================== */
 
+// Ground control point tying a pixel and line of a raster to a
+// georeferenced position
+type GCP struct {
+	ID    string  `json:"id"`
+	Info  string  `json:"info"`
+	Pixel float64 `json:"pixel"`
+	Line  float64 `json:"line"`
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Z     float64 `json:"z"`
+}
+
+// Convert GCPs to a C array, returning a function freeing its strings
+func cGCPs(gcps []GCP) ([]C.GDAL_GCP, func()) {
+	cgcps := make([]C.GDAL_GCP, len(gcps))
+	for i, gcp := range gcps {
+		cgcps[i] = C.GDAL_GCP{
+			pszId:      C.CString(gcp.ID),
+			pszInfo:    C.CString(gcp.Info),
+			dfGCPPixel: C.double(gcp.Pixel),
+			dfGCPLine:  C.double(gcp.Line),
+			dfGCPX:     C.double(gcp.X),
+			dfGCPY:     C.double(gcp.Y),
+			dfGCPZ:     C.double(gcp.Z),
+		}
+	}
+	return cgcps, func() {
+		for _, cgcp := range cgcps {
+			C.free(unsafe.Pointer(cgcp.pszId))
+			C.free(unsafe.Pointer(cgcp.pszInfo))
+		}
+	}
+}
+
+// Compute an affine transform approximating the GCPs.  If approxOK is
+// false, ok is false unless the transform fits every GCP within a quarter
+// pixel.
+func GCPsToGeoTransform(gcps []GCP, approxOK bool) (transform [6]float64, ok bool) {
+	if len(gcps) == 0 {
+		return transform, false
+	}
+	cgcps, free := cGCPs(gcps)
+	defer free()
+
+	success := C.GDALGCPsToGeoTransform(
+		C.int(len(cgcps)), &cgcps[0],
+		(*C.double)(unsafe.Pointer(&transform[0])),
+		BoolToCInt(approxOK),
+	)
+	return transform, success != 0
+}
+
 // Unimplemented: InitGCPs
 // Unimplemented: DeinitGCPs
 // Unimplemented: DuplicateGCPs
-// Unimplemented: GCPsToGeoTransform
 // Unimplemented: ApplyGeoTransform
 
 /* ==================================================================== */
@@ -576,9 +891,8 @@ func (object MajorObject) SetMetadata(metadata map[string]string, domain string)
 }
 
 // Fetch a single metadata item
-func (object MajorObject) MetadataItem(name, domain string) string {
-	panic("not implemented!")
-	return ""
+func (o MajorObject) MetadataItem(name, domain string) string {
+	return metadataItem(unsafe.Pointer(o.cval), name, domain)
 }
 
 // Set a single metadata item
@@ -588,41 +902,49 @@ func (o MajorObject) SetMetadataItem(name, value, domain string) error {
 
 // Fetch object description
 func (r RasterBand) Description() string {
+	r.check()
 	return description(unsafe.Pointer(r.cval))
 }
 
 // Set object description
 func (r RasterBand) SetDescription(desc string) {
+	r.check()
 	setDescription(unsafe.Pointer(r.cval), desc)
 }
 
 // Set a single metadata item
 func (r RasterBand) SetMetadataItem(name, value, domain string) error {
+	r.check()
 	return setMetadataItem(unsafe.Pointer(r.cval), name, value, domain)
 }
 
 // Fetch metadata, typically pass "" for default domain
 func (r RasterBand) Metadata(domain string) map[string]string {
+	r.check()
 	return metadata(unsafe.Pointer(r.cval), domain)
 }
 
 // Fetch object description
 func (d Dataset) Description() string {
+	d.check()
 	return description(unsafe.Pointer(d.cval))
 }
 
 // Set object description
 func (d Dataset) SetDescription(desc string) {
+	d.check()
 	setDescription(unsafe.Pointer(d.cval), desc)
 }
 
 // Set a single metadata item
 func (d Dataset) SetMetadataItem(name, value, domain string) error {
+	d.check()
 	return setMetadataItem(unsafe.Pointer(d.cval), name, value, domain)
 }
 
 // Fetch metadata, typically pass "" for default domain
 func (d Dataset) Metadata(domain string) map[string]string {
+	d.check()
 	return metadata(unsafe.Pointer(d.cval), domain)
 }
 
@@ -632,12 +954,14 @@ func (d Dataset) Metadata(domain string) map[string]string {
 
 // Get the driver to which this dataset relates
 func (dataset Dataset) Driver() Driver {
+	dataset.check()
 	driver := Driver{C.GDALGetDatasetDriver(dataset.cval)}
 	return driver
 }
 
 // Fetch files forming the dataset.
 func (dataset Dataset) FileList() []string {
+	dataset.check()
 	p := C.GDALGetFileList(dataset.cval)
 	var strings []string
 	q := uintptr(unsafe.Pointer(p))
@@ -653,38 +977,113 @@ func (dataset Dataset) FileList() []string {
 	return strings
 }
 
-// Close the dataset
-func (dataset Dataset) Close() {
-	C.GDALClose(dataset.cval)
-	return
+// Take ownership of a dataset handle returned by GDAL
+func ownDataset(h C.GDALDatasetH) Dataset {
+	return Dataset{h, newOwner("Dataset", func() error { return closeDataset(h) })}
+}
+
+// Take ownership of a dataset handle returned by Create or CreateCopy,
+// keeping the warnings emitted while creating it
+func ownCreatedDataset(h C.GDALDatasetH, messages []ErrorMessage) Dataset {
+	dataset := ownDataset(h)
+	for _, message := range messages {
+		if message.Class == CE_Warning {
+			dataset.own.warnings = append(dataset.own.warnings, message)
+		}
+	}
+	return dataset
+}
+
+// Close a dataset, reporting failures emitted while flushing pending writes
+func closeDataset(h C.GDALDatasetH) error {
+	if h == nil {
+		return nil
+	}
+	messages := captureErrors(func() { C.GDALClose(h) })
+	for _, message := range messages {
+		if message.Class >= CE_Failure {
+			return newError(CE_Failure, messages)
+		}
+	}
+	return nil
+}
+
+// Close the dataset, flushing pending writes.  Closing a dataset returned
+// by Open, Create or CreateCopy more than once is a no-op.
+func (dataset Dataset) Close() error {
+	return dataset.own.close(func() error { return closeDataset(dataset.cval) })
+}
+
+// Return the warnings GDAL emitted while creating the dataset with
+// Driver.Create or Driver.CreateCopy, or nil for none
+func (dataset Dataset) Warnings() []ErrorMessage {
+	if dataset.own == nil {
+		return nil
+	}
+	return append([]ErrorMessage(nil), dataset.own.warnings...)
 }
 
 // Fetch X size of raster
 func (dataset Dataset) RasterXSize() int {
+	dataset.check()
 	xSize := int(C.GDALGetRasterXSize(dataset.cval))
 	return xSize
 }
 
 // Fetch Y size of raster
 func (dataset Dataset) RasterYSize() int {
+	dataset.check()
 	ySize := int(C.GDALGetRasterYSize(dataset.cval))
 	return ySize
 }
 
 // Fetch the number of raster bands in the dataset
 func (dataset Dataset) RasterCount() int {
+	dataset.check()
 	count := int(C.GDALGetRasterCount(dataset.cval))
 	return count
 }
 
+// Fetch the number of vector layers in the dataset
+func (dataset Dataset) LayerCount() int {
+	dataset.check()
+	count := C.GDALDatasetGetLayerCount(dataset.cval)
+	return int(count)
+}
+
+// Fetch a vector layer of the dataset by index
+func (dataset Dataset) LayerByIndex(index int) Layer {
+	dataset.check()
+	layer := C.GDALDatasetGetLayer(dataset.cval, C.int(index))
+	return Layer{C.OGRLayerH(layer), dataset.own}
+}
+
+// Fetch a vector layer of the dataset by name
+func (dataset Dataset) LayerByName(name string) Layer {
+	dataset.check()
+	cString := C.CString(name)
+	defer C.free(unsafe.Pointer(cString))
+	layer := C.GDALDatasetGetLayerByName(dataset.cval, cString)
+	return Layer{C.OGRLayerH(layer), dataset.own}
+}
+
+// Return a DataSource view of the dataset, giving access to the vector
+// functions of the OGR API.  Closing either value closes the dataset.
+func (dataset Dataset) DataSource() DataSource {
+	dataset.check()
+	return DataSource{C.OGRDataSourceH(dataset.cval), dataset.own}
+}
+
 // Fetch a raster band object from a dataset
 func (dataset Dataset) RasterBand(band int) RasterBand {
-	rasterBand := RasterBand{C.GDALGetRasterBand(dataset.cval, C.int(band))}
+	dataset.check()
+	rasterBand := RasterBand{C.GDALGetRasterBand(dataset.cval, C.int(band)), dataset.own}
 	return rasterBand
 }
 
 // Add a band to a dataset
 func (dataset Dataset) AddBand(dataType DataType, options []string) error {
+	dataset.check()
 	length := len(options)
 	cOptions := make([]*C.char, length+1)
 	for i := 0; i < length; i++ {
@@ -693,11 +1092,13 @@ func (dataset Dataset) AddBand(dataType DataType, options []string) error {
 	}
 	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
 
-	return C.GDALAddBand(
-		dataset.cval,
-		C.GDALDataType(dataType),
-		(**C.char)(unsafe.Pointer(&cOptions[0])),
-	).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALAddBand(
+			dataset.cval,
+			C.GDALDataType(dataType),
+			(**C.char)(unsafe.Pointer(&cOptions[0])),
+		)
+	})
 }
 
 type ResampleAlg int
@@ -708,9 +1109,51 @@ const (
 	GRA_Cubic            = ResampleAlg(2)
 	GRA_CubicSpline      = ResampleAlg(3)
 	GRA_Lanczos          = ResampleAlg(4)
+	GRA_Average          = ResampleAlg(5)
+	GRA_Mode             = ResampleAlg(6)
+	GRA_Max              = ResampleAlg(8)
+	GRA_Min              = ResampleAlg(9)
+	GRA_Med              = ResampleAlg(10)
+	GRA_Q1               = ResampleAlg(11)
+	GRA_Q3               = ResampleAlg(12)
+	GRA_Sum              = ResampleAlg(13)
 )
 
+// Name of the resampling algorithm, as accepted by the utilities
+func (alg ResampleAlg) String() string {
+	switch alg {
+	case GRA_NearestNeighbour:
+		return "near"
+	case GRA_Bilinear:
+		return "bilinear"
+	case GRA_Cubic:
+		return "cubic"
+	case GRA_CubicSpline:
+		return "cubicspline"
+	case GRA_Lanczos:
+		return "lanczos"
+	case GRA_Average:
+		return "average"
+	case GRA_Mode:
+		return "mode"
+	case GRA_Max:
+		return "max"
+	case GRA_Min:
+		return "min"
+	case GRA_Med:
+		return "med"
+	case GRA_Q1:
+		return "q1"
+	case GRA_Q3:
+		return "q3"
+	case GRA_Sum:
+		return "sum"
+	}
+	return fmt.Sprintf("ResampleAlg(%d)", int(alg))
+}
+
 func (dataset Dataset) AutoCreateWarpedVRT(srcWKT, dstWKT string, resampleAlg ResampleAlg) (Dataset, error) {
+	dataset.check()
 	c_srcWKT := C.CString(srcWKT)
 	defer C.free(unsafe.Pointer(c_srcWKT))
 	c_dstWKT := C.CString(dstWKT)
@@ -718,16 +1161,19 @@ func (dataset Dataset) AutoCreateWarpedVRT(srcWKT, dstWKT string, resampleAlg Re
 	/*
 
 	 */
-	h := C.GDALAutoCreateWarpedVRT(dataset.cval, c_srcWKT, c_dstWKT, C.GDALResampleAlg(resampleAlg), 0.0, nil)
-	d := Dataset{h}
+	var h C.GDALDatasetH
+	messages := captureErrors(func() {
+		h = C.GDALAutoCreateWarpedVRT(dataset.cval, c_srcWKT, c_dstWKT, C.GDALResampleAlg(resampleAlg), 0.0, nil)
+	})
 	if h == nil {
-		return d, fmt.Errorf("AutoCreateWarpedVRT failed")
+		return Dataset{}, failure(messages, "AutoCreateWarpedVRT failed")
 	}
-	return d, nil
+	return ownDataset(h), nil
 
 }
 
 func (dataset Dataset) AutoCreateWarpedVRTwithWarpOpts(srcWKT, dstWKT string, resampleAlg ResampleAlg, options WarpOptions) (Dataset, error) {
+	dataset.check()
 	c_srcWKT := C.CString(srcWKT)
 	defer C.free(unsafe.Pointer(c_srcWKT))
 	c_dstWKT := C.CString(dstWKT)
@@ -735,73 +1181,245 @@ func (dataset Dataset) AutoCreateWarpedVRTwithWarpOpts(srcWKT, dstWKT string, re
 	/*
 
 	 */
-	h := C.GDALAutoCreateWarpedVRT(dataset.cval, c_srcWKT, c_dstWKT, C.GDALResampleAlg(resampleAlg), 0.0, options)
-	d := Dataset{h}
+	var h C.GDALDatasetH
+	messages := captureErrors(func() {
+		h = C.GDALAutoCreateWarpedVRT(dataset.cval, c_srcWKT, c_dstWKT, C.GDALResampleAlg(resampleAlg), 0.0, options)
+	})
 	if h == nil {
-		return d, fmt.Errorf("AutoCreateWarpedVRT failed")
+		return Dataset{}, failure(messages, "AutoCreateWarpedVRT failed")
 	}
-	return d, nil
+	return ownDataset(h), nil
 
 }
 
-// Unimplemented: GDALBeginAsyncReader
-// Unimplemented: GDALEndAsyncReader
-
-// Read / write a region of image data from multiple bands
-func (dataset Dataset) IO(
-	rwFlag RWFlag,
+// Start reading a region of the dataset asynchronously into buffer, with
+// the same arguments as IO.  Formats without native support, which are
+// most of them, read the whole region when the first updated region is
+// requested.  buffer must not be accessed outside LockBuffer and
+// UnlockBuffer until End is called.
+func (dataset Dataset) BeginAsyncReader(
 	xOff, yOff, xSize, ySize int,
 	buffer interface{},
 	bufXSize, bufYSize int,
 	bandCount int,
 	bandMap []int,
 	pixelSpace, lineSpace, bandSpace int,
-) error {
-	var dataType DataType
-	var dataPtr unsafe.Pointer
+	options []string,
+) (AsyncReader, error) {
+	dataset.check()
+	b, err := dataset.datasetBuffer(
+		xOff, yOff, xSize, ySize, buffer, bufXSize, bufYSize,
+		bandCount, bandMap, pixelSpace, lineSpace, bandSpace,
+	)
+	if err != nil {
+		return AsyncReader{}, err
+	}
+	if b.extent == 0 {
+		return AsyncReader{}, fmt.Errorf("Error: cannot read an empty region asynchronously")
+	}
+
+	length := len(options)
+	opts := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		opts[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(opts[i]))
+	}
+	opts[length] = (*C.char)(unsafe.Pointer(nil))
+
+	// GDAL writes to the buffer after this call returns
+	pinner := new(runtime.Pinner)
+	pinner.Pin(b.data)
+
+	var h C.GDALAsyncReaderH
+	messages := captureErrors(func() {
+		h = C.GDALBeginAsyncReader(
+			dataset.cval,
+			C.int(xOff), C.int(yOff), C.int(xSize), C.int(ySize),
+			b.data,
+			C.int(bufXSize), C.int(bufYSize),
+			C.GDALDataType(b.dataType),
+			C.int(len(b.bandMap)),
+			&b.bandMap[0],
+			C.int(b.pixelSpace), C.int(b.lineSpace), C.int(b.bandSpace),
+			(**C.char)(unsafe.Pointer(&opts[0])),
+		)
+	})
+	if h == nil {
+		pinner.Unpin()
+		return AsyncReader{}, failure(messages, "Error: asynchronous reader creation failed")
+	}
+	ds := dataset.cval
+	return AsyncReader{h, newOwner("AsyncReader", func() error {
+		C.GDALEndAsyncReader(ds, h)
+		pinner.Unpin()
+		return nil
+	}), dataset.own}, nil
+}
+
+// Return the data type, address and length of a numeric slice used as a
+// raster IO buffer
+func bufferPointer(buffer interface{}) (DataType, unsafe.Pointer, int, error) {
 	switch data := buffer.(type) {
 	case []uint8:
-		dataType = Byte
-		dataPtr = unsafe.Pointer(&data[0])
+		return Byte, slicePointer(data), len(data), nil
 	case []int16:
-		dataType = Int16
-		dataPtr = unsafe.Pointer(&data[0])
+		return Int16, slicePointer(data), len(data), nil
 	case []uint16:
-		dataType = UInt16
-		dataPtr = unsafe.Pointer(&data[0])
+		return UInt16, slicePointer(data), len(data), nil
 	case []int32:
-		dataType = Int32
-		dataPtr = unsafe.Pointer(&data[0])
+		return Int32, slicePointer(data), len(data), nil
 	case []uint32:
-		dataType = UInt32
-		dataPtr = unsafe.Pointer(&data[0])
+		return UInt32, slicePointer(data), len(data), nil
 	case []float32:
-		dataType = Float32
-		dataPtr = unsafe.Pointer(&data[0])
+		return Float32, slicePointer(data), len(data), nil
 	case []float64:
-		dataType = Float64
-		dataPtr = unsafe.Pointer(&data[0])
+		return Float64, slicePointer(data), len(data), nil
+	case []ComplexInt16:
+		return CInt16, slicePointer(data), len(data), nil
+	case []ComplexInt32:
+		return CInt32, slicePointer(data), len(data), nil
 	case []complex64:
-		dataType = CFloat32
-		dataPtr = unsafe.Pointer(&data[0])
+		return CFloat32, slicePointer(data), len(data), nil
 	case []complex128:
-		dataType = CFloat64
-		dataPtr = unsafe.Pointer(&data[0])
-	default:
-		return fmt.Errorf("Error: buffer is not a valid data type (must be a valid numeric slice)")
+		return CFloat64, slicePointer(data), len(data), nil
+	}
+	return Unknown, nil, 0, fmt.Errorf("Error: buffer is not a valid data type (must be a valid numeric slice)")
+}
+
+// Check that a window lies within a raster of the given size
+func validateWindow(xOff, yOff, xSize, ySize, rasterXSize, rasterYSize int) error {
+	if xOff < 0 || yOff < 0 || xSize < 0 || ySize < 0 ||
+		xOff+xSize > rasterXSize || yOff+ySize > rasterYSize {
+		return fmt.Errorf(
+			"Error: window %dx%d at (%d, %d) is outside the %dx%d raster",
+			xSize, ySize, xOff, yOff, rasterXSize, rasterYSize,
+		)
+	}
+	return nil
+}
+
+// Validated arguments for reading or writing several bands of a dataset
+type datasetBuffer struct {
+	dataType                         DataType
+	data                             unsafe.Pointer
+	bandMap                          []C.int
+	pixelSpace, lineSpace, bandSpace int
+	extent                           int
+}
+
+// Check a buffer and window for dataset IO, defaulting the band map to
+// the first bandCount bands, or all bands, and spacings to a band
+// sequential buffer
+func (dataset Dataset) datasetBuffer(
+	xOff, yOff, xSize, ySize int,
+	buffer interface{},
+	bufXSize, bufYSize int,
+	bandCount int,
+	bandMap []int,
+	pixelSpace, lineSpace, bandSpace int,
+) (datasetBuffer, error) {
+	dataType, dataPtr, length, err := bufferPointer(buffer)
+	if err != nil {
+		return datasetBuffer{}, err
+	}
+	err = validateWindow(xOff, yOff, xSize, ySize, dataset.RasterXSize(), dataset.RasterYSize())
+	if err != nil {
+		return datasetBuffer{}, err
+	}
+
+	if bandCount < 0 {
+		return datasetBuffer{}, fmt.Errorf("Error: invalid band count %d", bandCount)
+	}
+	rasterCount := dataset.RasterCount()
+	if bandMap == nil {
+		if bandCount == 0 {
+			bandCount = rasterCount
+		}
+		bandMap = make([]int, bandCount)
+		for i := range bandMap {
+			bandMap[i] = i + 1
+		}
+	} else if bandCount == 0 {
+		bandCount = len(bandMap)
+	}
+	if bandCount > len(bandMap) {
+		return datasetBuffer{}, fmt.Errorf("Error: band count %d does not match band map of %d bands", bandCount, len(bandMap))
+	}
+	for _, band := range bandMap[:bandCount] {
+		if band < 1 || band > rasterCount {
+			return datasetBuffer{}, fmt.Errorf("Error: band %d is out of range (dataset has %d bands)", band, rasterCount)
+		}
+	}
+
+	pixelSize := dataType.Size() / 8
+	extent, pixelSpace, lineSpace, err := bufferExtent(bufXSize, bufYSize, pixelSize, pixelSpace, lineSpace)
+	if err != nil {
+		return datasetBuffer{}, err
+	}
+	if bandSpace < 0 {
+		return datasetBuffer{}, fmt.Errorf("Error: negative band spacing (%d) is not supported", bandSpace)
+	}
+	if bandSpace == 0 {
+		bandSpace = lineSpace * bufYSize
+	}
+	if extent > 0 && bandCount > 0 {
+		extent += (bandCount - 1) * bandSpace
+	} else {
+		extent = 0
+	}
+	if extent > length*pixelSize {
+		return datasetBuffer{}, fmt.Errorf(
+			"Error: buffer of %d %s pixels is too small for %d bands of %dx%d (needs %d bytes, has %d)",
+			length, dataType.Name(), bandCount, bufXSize, bufYSize, extent, length*pixelSize,
+		)
 	}
 
-	return C.GDALDatasetRasterIO(
-		dataset.cval,
-		C.GDALRWFlag(rwFlag),
-		C.int(xOff), C.int(yOff), C.int(xSize), C.int(ySize),
-		dataPtr,
-		C.int(bufXSize), C.int(bufYSize),
-		C.GDALDataType(dataType),
-		C.int(bandCount),
-		(*C.int)(unsafe.Pointer(&IntSliceToCInt(bandMap)[0])),
-		C.int(pixelSpace), C.int(lineSpace), C.int(bandSpace),
-	).Err()
+	return datasetBuffer{
+		dataType:   dataType,
+		data:       dataPtr,
+		bandMap:    IntSliceToCInt(bandMap[:bandCount]),
+		pixelSpace: pixelSpace,
+		lineSpace:  lineSpace,
+		bandSpace:  bandSpace,
+		extent:     extent,
+	}, nil
+}
+
+// Read / write a region of image data from multiple bands
+func (dataset Dataset) IO(
+	rwFlag RWFlag,
+	xOff, yOff, xSize, ySize int,
+	buffer interface{},
+	bufXSize, bufYSize int,
+	bandCount int,
+	bandMap []int,
+	pixelSpace, lineSpace, bandSpace int,
+) error {
+	dataset.check()
+	b, err := dataset.datasetBuffer(
+		xOff, yOff, xSize, ySize, buffer, bufXSize, bufYSize,
+		bandCount, bandMap, pixelSpace, lineSpace, bandSpace,
+	)
+	if err != nil || b.extent == 0 {
+		return err
+	}
+
+	err = cplCall(func() C.CPLErr {
+		return C.GDALDatasetRasterIO(
+			dataset.cval,
+			C.GDALRWFlag(rwFlag),
+			C.int(xOff), C.int(yOff), C.int(xSize), C.int(ySize),
+			b.data,
+			C.int(bufXSize), C.int(bufYSize),
+			C.GDALDataType(b.dataType),
+			C.int(len(b.bandMap)),
+			&b.bandMap[0],
+			C.int(b.pixelSpace), C.int(b.lineSpace), C.int(b.bandSpace),
+		)
+	})
+	// Keep the dataset from being finalized, closing it, during the call
+	runtime.KeepAlive(dataset.own)
+	return err
 }
 
 // Advise driver of upcoming read requests
@@ -813,6 +1431,7 @@ func (dataset Dataset) AdviseRead(
 	bandMap []int,
 	options []string,
 ) error {
+	dataset.check()
 	length := len(options)
 	cOptions := make([]*C.char, length+1)
 	for i := 0; i < length; i++ {
@@ -821,33 +1440,40 @@ func (dataset Dataset) AdviseRead(
 	}
 	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
 
-	return C.GDALDatasetAdviseRead(
-		dataset.cval,
-		C.int(xOff), C.int(yOff), C.int(xSize), C.int(ySize),
-		C.int(bufXSize), C.int(bufYSize),
-		C.GDALDataType(dataType),
-		C.int(bandCount),
-		(*C.int)(unsafe.Pointer(&IntSliceToCInt(bandMap)[0])),
-		(**C.char)(unsafe.Pointer(&cOptions[0])),
-	).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALDatasetAdviseRead(
+			dataset.cval,
+			C.int(xOff), C.int(yOff), C.int(xSize), C.int(ySize),
+			C.int(bufXSize), C.int(bufYSize),
+			C.GDALDataType(dataType),
+			C.int(bandCount),
+			(*C.int)(unsafe.Pointer(&IntSliceToCInt(bandMap)[0])),
+			(**C.char)(unsafe.Pointer(&cOptions[0])),
+		)
+	})
 }
 
 // Fetch the projection definition string for this dataset
 func (dataset Dataset) Projection() string {
+	dataset.check()
 	proj := C.GoString(C.GDALGetProjectionRef(dataset.cval))
 	return proj
 }
 
 // Set the projection reference string
 func (dataset Dataset) SetProjection(proj string) error {
+	dataset.check()
 	cProj := C.CString(proj)
 	defer C.free(unsafe.Pointer(cProj))
 
-	return C.GDALSetProjection(dataset.cval, cProj).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALSetProjection(dataset.cval, cProj)
+	})
 }
 
 // Get the affine transformation coefficients
 func (dataset Dataset) GeoTransform() [6]float64 {
+	dataset.check()
 	var transform [6]float64
 	C.GDALGetGeoTransform(dataset.cval, (*C.double)(unsafe.Pointer(&transform[0])))
 	return transform
@@ -855,14 +1481,18 @@ func (dataset Dataset) GeoTransform() [6]float64 {
 
 // Set the affine transformation coefficients
 func (dataset Dataset) SetGeoTransform(transform [6]float64) error {
-	return C.GDALSetGeoTransform(
-		dataset.cval,
-		(*C.double)(unsafe.Pointer(&transform[0])),
-	).Err()
+	dataset.check()
+	return cplCall(func() C.CPLErr {
+		return C.GDALSetGeoTransform(
+			dataset.cval,
+			(*C.double)(unsafe.Pointer(&transform[0])),
+		)
+	})
 }
 
 // Return the inverted transform
 func (dataset Dataset) InvGeoTransform() [6]float64 {
+	dataset.check()
 	return InvGeoTransform(dataset.GeoTransform())
 }
 
@@ -875,16 +1505,60 @@ func InvGeoTransform(transform [6]float64) [6]float64 {
 
 // Get number of GCPs
 func (dataset Dataset) GDALGetGCPCount() int {
+	dataset.check()
 	count := C.GDALGetGCPCount(dataset.cval)
 	return int(count)
 }
 
-// Unimplemented: GDALGetGCPProjection
-// Unimplemented: GDALGetGCPs
-// Unimplemented: GDALSetGCPs
+// Fetch the projection definition string of the GCPs
+func (dataset Dataset) GCPProjection() string {
+	dataset.check()
+	return C.GoString(C.GDALGetGCPProjection(dataset.cval))
+}
+
+// Fetch the GCPs of the dataset
+func (dataset Dataset) GCPs() []GCP {
+	dataset.check()
+	count := int(C.GDALGetGCPCount(dataset.cval))
+	if count == 0 {
+		return nil
+	}
+	cgcps := unsafe.Slice(C.GDALGetGCPs(dataset.cval), count)
+	gcps := make([]GCP, count)
+	for i, cgcp := range cgcps {
+		gcps[i] = GCP{
+			ID:    C.GoString(cgcp.pszId),
+			Info:  C.GoString(cgcp.pszInfo),
+			Pixel: float64(cgcp.dfGCPPixel),
+			Line:  float64(cgcp.dfGCPLine),
+			X:     float64(cgcp.dfGCPX),
+			Y:     float64(cgcp.dfGCPY),
+			Z:     float64(cgcp.dfGCPZ),
+		}
+	}
+	return gcps
+}
+
+// Assign GCPs and the definition of their projection
+func (dataset Dataset) SetGCPs(gcps []GCP, projection string) error {
+	dataset.check()
+	cgcps, free := cGCPs(gcps)
+	defer free()
+	cProj := C.CString(projection)
+	defer C.free(unsafe.Pointer(cProj))
+
+	var first *C.GDAL_GCP
+	if len(cgcps) > 0 {
+		first = &cgcps[0]
+	}
+	return cplCall(func() C.CPLErr {
+		return C.GDALSetGCPs(dataset.cval, C.int(len(cgcps)), first, cProj)
+	})
+}
 
 // Fetch a format specific internally meaningful handle
 func (dataset Dataset) GDALGetInternalHandle(request string) unsafe.Pointer {
+	dataset.check()
 	cRequest := C.CString(request)
 	defer C.free(unsafe.Pointer(cRequest))
 
@@ -894,12 +1568,14 @@ func (dataset Dataset) GDALGetInternalHandle(request string) unsafe.Pointer {
 
 // Add one to dataset reference count
 func (dataset Dataset) GDALReferenceDataset() int {
+	dataset.check()
 	count := C.GDALReferenceDataset(dataset.cval)
 	return int(count)
 }
 
 // Subtract one from dataset reference count
 func (dataset Dataset) GDALDereferenceDataset() int {
+	dataset.check()
 	count := C.GDALDereferenceDataset(dataset.cval)
 	return int(count)
 }
@@ -914,40 +1590,69 @@ func (dataset Dataset) BuildOverviews(
 	progress ProgressFunc,
 	data interface{},
 ) error {
+	dataset.check()
 	cResampling := C.CString(resampling)
 	defer C.free(unsafe.Pointer(cResampling))
 
-	arg := &goGDALProgressFuncProxyArgs{progress, data}
+	pf, pa, release := progressProxy(progress, data)
+	defer release()
+
+	return cplCall(func() C.CPLErr {
+		return C.GDALBuildOverviews(
+			dataset.cval,
+			cResampling,
+			C.int(nOverviews),
+			(*C.int)(unsafe.Pointer(&IntSliceToCInt(overviewList)[0])),
+			C.int(nBands),
+			(*C.int)(unsafe.Pointer(&IntSliceToCInt(bandList)[0])),
+			pf,
+			pa,
+		)
+	})
+}
 
-	return C.GDALBuildOverviews(
-		dataset.cval,
-		cResampling,
-		C.int(nOverviews),
-		(*C.int)(unsafe.Pointer(&IntSliceToCInt(overviewList)[0])),
-		C.int(nBands),
-		(*C.int)(unsafe.Pointer(&IntSliceToCInt(bandList)[0])),
-		C.goGDALProgressFuncProxyB(),
-		unsafe.Pointer(arg),
-	).Err()
+// Build raster overview(s), aborting when ctx is done.  The returned error
+// wraps ctx.Err() if the operation was aborted.
+func (dataset Dataset) BuildOverviewsContext(
+	ctx context.Context,
+	resampling string,
+	nOverviews int,
+	overviewList []int,
+	nBands int,
+	bandList []int,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	dataset.check()
+	err := dataset.BuildOverviews(
+		resampling, nOverviews, overviewList, nBands, bandList,
+		ProgressFromContext(ctx, progress), data,
+	)
+	return contextError(ctx, err)
 }
 
 // Unimplemented: GDALGetOpenDatasets
 
 // Return access flag
 func (dataset Dataset) Access() Access {
+	dataset.check()
 	accessVal := C.GDALGetAccess(dataset.cval)
 	return Access(accessVal)
 }
 
 // Write all write cached data to disk
 func (dataset Dataset) FlushCache() {
+	dataset.check()
 	C.GDALFlushCache(dataset.cval)
 	return
 }
 
 // Adds a mask band to the dataset
 func (dataset Dataset) CreateMaskBand(flags int) error {
-	return C.GDALCreateDatasetMaskBand(dataset.cval, C.int(flags)).Err()
+	dataset.check()
+	return cplCall(func() C.CPLErr {
+		return C.GDALCreateDatasetMaskBand(dataset.cval, C.int(flags))
+	})
 }
 
 // Copy all dataset raster data
@@ -957,7 +1662,9 @@ func (sourceDataset Dataset) CopyWholeRaster(
 	progress ProgressFunc,
 	data interface{},
 ) error {
-	arg := &goGDALProgressFuncProxyArgs{progress, data}
+	sourceDataset.check()
+	pf, pa, release := progressProxy(progress, data)
+	defer release()
 
 	length := len(options)
 	cOptions := make([]*C.char, length+1)
@@ -967,13 +1674,31 @@ func (sourceDataset Dataset) CopyWholeRaster(
 	}
 	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
 
-	return C.GDALDatasetCopyWholeRaster(
-		sourceDataset.cval,
-		destDataset.cval,
-		(**C.char)(unsafe.Pointer(&cOptions[0])),
-		C.goGDALProgressFuncProxyB(),
-		unsafe.Pointer(arg),
-	).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALDatasetCopyWholeRaster(
+			sourceDataset.cval,
+			destDataset.cval,
+			(**C.char)(unsafe.Pointer(&cOptions[0])),
+			pf,
+			pa,
+		)
+	})
+}
+
+// Copy all dataset raster data, aborting when ctx is done.  The returned
+// error wraps ctx.Err() if the copy was aborted.
+func (sourceDataset Dataset) CopyWholeRasterContext(
+	ctx context.Context,
+	destDataset Dataset,
+	options []string,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	sourceDataset.check()
+	err := sourceDataset.CopyWholeRaster(
+		destDataset, options, ProgressFromContext(ctx, progress), data,
+	)
+	return contextError(ctx, err)
 }
 
 /* ==================================================================== */
@@ -982,12 +1707,14 @@ func (sourceDataset Dataset) CopyWholeRaster(
 
 // Fetch the pixel data type for this band
 func (rasterBand RasterBand) RasterDataType() DataType {
+	rasterBand.check()
 	dataType := C.GDALGetRasterDataType(rasterBand.cval)
 	return DataType(dataType)
 }
 
 // Fetch the "natural" block size of this band
 func (rasterBand RasterBand) BlockSize() (int, int) {
+	rasterBand.check()
 	var xSize, ySize int
 	C.GDALGetBlockSize(rasterBand.cval, (*C.int)(unsafe.Pointer(&xSize)), (*C.int)(unsafe.Pointer(&ySize)))
 	return xSize, ySize
@@ -999,6 +1726,7 @@ func (rasterBand RasterBand) AdviseRead(
 	dataType DataType,
 	options []string,
 ) error {
+	rasterBand.check()
 	length := len(options)
 	cOptions := make([]*C.char, length+1)
 	for i := 0; i < length; i++ {
@@ -1007,12 +1735,14 @@ func (rasterBand RasterBand) AdviseRead(
 	}
 	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
 
-	return C.GDALRasterAdviseRead(
-		rasterBand.cval,
-		C.int(xOff), C.int(yOff), C.int(xSize), C.int(ySize), C.int(bufXSize), C.int(bufYSize),
-		C.GDALDataType(dataType),
-		(**C.char)(unsafe.Pointer(&cOptions[0])),
-	).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALRasterAdviseRead(
+			rasterBand.cval,
+			C.int(xOff), C.int(yOff), C.int(xSize), C.int(ySize), C.int(bufXSize), C.int(bufYSize),
+			C.GDALDataType(dataType),
+			(**C.char)(unsafe.Pointer(&cOptions[0])),
+		)
+	})
 }
 
 // Read / Write a region of image data for this band
@@ -1023,133 +1753,152 @@ func (rasterBand RasterBand) IO(
 	bufXSize, bufYSize int,
 	pixelSpace, lineSpace int,
 ) error {
-	var dataType DataType
-	var dataPtr unsafe.Pointer
-	switch data := buffer.(type) {
-	case []uint8:
-		dataType = Byte
-		dataPtr = unsafe.Pointer(&data[0])
-	case []int16:
-		dataType = Int16
-		dataPtr = unsafe.Pointer(&data[0])
-	case []uint16:
-		dataType = UInt16
-		dataPtr = unsafe.Pointer(&data[0])
-	case []int32:
-		dataType = Int32
-		dataPtr = unsafe.Pointer(&data[0])
-	case []uint32:
-		dataType = UInt32
-		dataPtr = unsafe.Pointer(&data[0])
-	case []float32:
-		dataType = Float32
-		dataPtr = unsafe.Pointer(&data[0])
-	case []float64:
-		dataType = Float64
-		dataPtr = unsafe.Pointer(&data[0])
-	case []complex64:
-		dataType = CFloat32
-		dataPtr = unsafe.Pointer(&data[0])
-	case []complex128:
-		dataType = CFloat64
-		dataPtr = unsafe.Pointer(&data[0])
-	default:
-		return fmt.Errorf("Error: buffer is not a valid data type (must be a valid numeric slice)")
+	rasterBand.check()
+	dataType, dataPtr, length, err := bufferPointer(buffer)
+	if err != nil {
+		return err
+	}
+	err = validateWindow(xOff, yOff, xSize, ySize, rasterBand.XSize(), rasterBand.YSize())
+	if err != nil {
+		return err
 	}
 
-	return C.GDALRasterIO(
-		rasterBand.cval,
-		C.GDALRWFlag(rwFlag),
-		C.int(xOff), C.int(yOff), C.int(xSize), C.int(ySize),
-		dataPtr,
-		C.int(bufXSize), C.int(bufYSize),
-		C.GDALDataType(dataType),
-		C.int(pixelSpace), C.int(lineSpace),
-	).Err()
+	pixelSize := dataType.Size() / 8
+	extent, pixelSpace, lineSpace, err := bufferExtent(bufXSize, bufYSize, pixelSize, pixelSpace, lineSpace)
+	if err != nil {
+		return err
+	}
+	if extent > length*pixelSize {
+		return fmt.Errorf(
+			"Error: buffer of %d %s pixels is too small for a %dx%d window (needs %d bytes, has %d)",
+			length, dataType.Name(), bufXSize, bufYSize, extent, length*pixelSize,
+		)
+	}
+	if extent == 0 {
+		return nil
+	}
+
+	err = cplCall(func() C.CPLErr {
+		return C.GDALRasterIO(
+			rasterBand.cval,
+			C.GDALRWFlag(rwFlag),
+			C.int(xOff), C.int(yOff), C.int(xSize), C.int(ySize),
+			dataPtr,
+			C.int(bufXSize), C.int(bufYSize),
+			C.GDALDataType(dataType),
+			C.int(pixelSpace), C.int(lineSpace),
+		)
+	})
+	// Keep the dataset of the band from being finalized during the call
+	runtime.KeepAlive(rasterBand.parent)
+	return err
 }
 
 // Read a block of image data efficiently
 func (rasterBand RasterBand) ReadBlock(xOff, yOff int, dataPtr unsafe.Pointer) error {
-	return C.GDALReadBlock(rasterBand.cval, C.int(xOff), C.int(yOff), dataPtr).Err()
+	rasterBand.check()
+	return cplCall(func() C.CPLErr {
+		return C.GDALReadBlock(rasterBand.cval, C.int(xOff), C.int(yOff), dataPtr)
+	})
 }
 
 // Write a block of image data efficiently
 func (rasterBand RasterBand) WriteBlock(xOff, yOff int, dataPtr unsafe.Pointer) error {
-	return C.GDALWriteBlock(rasterBand.cval, C.int(xOff), C.int(yOff), dataPtr).Err()
+	rasterBand.check()
+	return cplCall(func() C.CPLErr {
+		return C.GDALWriteBlock(rasterBand.cval, C.int(xOff), C.int(yOff), dataPtr)
+	})
 }
 
 // Fetch X size of raster
 func (rasterBand RasterBand) XSize() int {
+	rasterBand.check()
 	xSize := C.GDALGetRasterBandXSize(rasterBand.cval)
 	return int(xSize)
 }
 
 // Fetch Y size of raster
 func (rasterBand RasterBand) YSize() int {
+	rasterBand.check()
 	ySize := C.GDALGetRasterBandYSize(rasterBand.cval)
 	return int(ySize)
 }
 
 // Find out if we have update permission for this band
 func (rasterBand RasterBand) GetAccess() Access {
+	rasterBand.check()
 	access := C.GDALGetRasterAccess(rasterBand.cval)
 	return Access(access)
 }
 
 // Fetch the band number of this raster band
 func (rasterBand RasterBand) BandNumber() int {
+	rasterBand.check()
 	bandNumber := C.GDALGetBandNumber(rasterBand.cval)
 	return int(bandNumber)
 }
 
 // Fetch the owning dataset handle
 func (rasterBand RasterBand) GetDataset() Dataset {
+	rasterBand.check()
 	dataset := C.GDALGetBandDataset(rasterBand.cval)
-	return Dataset{dataset}
+	return Dataset{cval: dataset}
 }
 
 // How should this band be interpreted as color?
 func (rasterBand RasterBand) ColorInterp() ColorInterp {
+	rasterBand.check()
 	colorInterp := C.GDALGetRasterColorInterpretation(rasterBand.cval)
 	return ColorInterp(colorInterp)
 }
 
 // Set color interpretation of the raster band
 func (rasterBand RasterBand) SetColorInterp(colorInterp ColorInterp) error {
-	return C.GDALSetRasterColorInterpretation(rasterBand.cval, C.GDALColorInterp(colorInterp)).Err()
+	rasterBand.check()
+	return cplCall(func() C.CPLErr {
+		return C.GDALSetRasterColorInterpretation(rasterBand.cval, C.GDALColorInterp(colorInterp))
+	})
 }
 
 // Fetch the color table associated with this raster band
 func (rasterBand RasterBand) ColorTable() ColorTable {
+	rasterBand.check()
 	colorTable := C.GDALGetRasterColorTable(rasterBand.cval)
-	return ColorTable{colorTable}
+	return ColorTable{cval: colorTable}
 }
 
 // Set the raster color table for this raster band
 func (rasterBand RasterBand) SetColorTable(colorTable ColorTable) error {
-	return C.GDALSetRasterColorTable(rasterBand.cval, colorTable.cval).Err()
+	rasterBand.check()
+	return cplCall(func() C.CPLErr {
+		return C.GDALSetRasterColorTable(rasterBand.cval, colorTable.cval)
+	})
 }
 
 // Check for arbitrary overviews
 func (rasterBand RasterBand) HasArbitraryOverviews() int {
+	rasterBand.check()
 	yes := C.GDALHasArbitraryOverviews(rasterBand.cval)
 	return int(yes)
 }
 
 // Return the number of overview layers available
 func (rasterBand RasterBand) OverviewCount() int {
+	rasterBand.check()
 	count := C.GDALGetOverviewCount(rasterBand.cval)
 	return int(count)
 }
 
 // Fetch overview raster band object
 func (rasterBand RasterBand) Overview(level int) RasterBand {
+	rasterBand.check()
 	overview := C.GDALGetOverview(rasterBand.cval, C.int(level))
-	return RasterBand{overview}
+	return RasterBand{overview, rasterBand.parent}
 }
 
 // Fetch the no data value for this band
 func (rasterBand RasterBand) NoDataValue() (val float64, valid bool) {
+	rasterBand.check()
 	var success int
 	noDataVal := C.GDALGetRasterNoDataValue(rasterBand.cval, (*C.int)(unsafe.Pointer(&success)))
 	return float64(noDataVal), success != 0
@@ -1157,11 +1906,15 @@ func (rasterBand RasterBand) NoDataValue() (val float64, valid bool) {
 
 // Set the no data value for this band
 func (rasterBand RasterBand) SetNoDataValue(val float64) error {
-	return C.GDALSetRasterNoDataValue(rasterBand.cval, C.double(val)).Err()
+	rasterBand.check()
+	return cplCall(func() C.CPLErr {
+		return C.GDALSetRasterNoDataValue(rasterBand.cval, C.double(val))
+	})
 }
 
 // Fetch the list of category names for this raster
 func (rasterBand RasterBand) CategoryNames() []string {
+	rasterBand.check()
 	p := C.GDALGetRasterCategoryNames(rasterBand.cval)
 	if p == nil {
 		return nil
@@ -1183,6 +1936,7 @@ func (rasterBand RasterBand) CategoryNames() []string {
 
 // Set the category names for this band
 func (rasterBand RasterBand) SetRasterCategoryNames(names []string) error {
+	rasterBand.check()
 	length := len(names)
 	cStrings := make([]*C.char, length+1)
 	for i := 0; i < length; i++ {
@@ -1191,11 +1945,14 @@ func (rasterBand RasterBand) SetRasterCategoryNames(names []string) error {
 	}
 	cStrings[length] = (*C.char)(unsafe.Pointer(nil))
 
-	return C.GDALSetRasterCategoryNames(rasterBand.cval, (**C.char)(unsafe.Pointer(&cStrings[0]))).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALSetRasterCategoryNames(rasterBand.cval, (**C.char)(unsafe.Pointer(&cStrings[0])))
+	})
 }
 
 // Fetch the minimum value for this band
 func (rasterBand RasterBand) GetMinimum() (val float64, valid bool) {
+	rasterBand.check()
 	var success int
 	min := C.GDALGetRasterMinimum(rasterBand.cval, (*C.int)(unsafe.Pointer(&success)))
 	return float64(min), success != 0
@@ -1203,6 +1960,7 @@ func (rasterBand RasterBand) GetMinimum() (val float64, valid bool) {
 
 // Fetch the maximum value for this band
 func (rasterBand RasterBand) GetMaximum() (val float64, valid bool) {
+	rasterBand.check()
 	var success int
 	max := C.GDALGetRasterMaximum(rasterBand.cval, (*C.int)(unsafe.Pointer(&success)))
 	return float64(max), success != 0
@@ -1210,6 +1968,7 @@ func (rasterBand RasterBand) GetMaximum() (val float64, valid bool) {
 
 // Fetch image statistics
 func (rasterBand RasterBand) GetStatistics(approxOK, force int) (min, max, mean, stdDev float64) {
+	rasterBand.check()
 	C.GDALGetRasterStatistics(
 		rasterBand.cval,
 		C.int(approxOK),
@@ -1228,7 +1987,9 @@ func (rasterBand RasterBand) ComputeStatistics(
 	progress ProgressFunc,
 	data interface{},
 ) (min, max, mean, stdDev float64) {
-	arg := &goGDALProgressFuncProxyArgs{progress, data}
+	rasterBand.check()
+	pf, pa, release := progressProxy(progress, data)
+	defer release()
 
 	C.GDALComputeRasterStatistics(
 		rasterBand.cval,
@@ -1237,39 +1998,86 @@ func (rasterBand RasterBand) ComputeStatistics(
 		(*C.double)(unsafe.Pointer(&max)),
 		(*C.double)(unsafe.Pointer(&mean)),
 		(*C.double)(unsafe.Pointer(&stdDev)),
-		C.goGDALProgressFuncProxyB(),
-		unsafe.Pointer(arg),
+		pf,
+		pa,
 	)
 	return min, max, mean, stdDev
 }
 
 // Set statistics on raster band
 func (rasterBand RasterBand) SetStatistics(min, max, mean, stdDev float64) error {
-	return C.GDALSetRasterStatistics(
-		rasterBand.cval,
-		C.double(min),
-		C.double(max),
-		C.double(mean),
-		C.double(stdDev),
-	).Err()
+	rasterBand.check()
+	return cplCall(func() C.CPLErr {
+		return C.GDALSetRasterStatistics(
+			rasterBand.cval,
+			C.double(min),
+			C.double(max),
+			C.double(mean),
+			C.double(stdDev),
+		)
+	})
+}
+
+// Compute the histogram of the band over buckets between min and max
+func (rasterBand RasterBand) Histogram(
+	min, max float64,
+	buckets int,
+	includeOutOfRange, approxOK int,
+	progress ProgressFunc,
+	data interface{},
+) ([]int, error) {
+	rasterBand.check()
+	if buckets <= 0 {
+		return nil, fmt.Errorf("Error: invalid histogram bucket count %d", buckets)
+	}
+	pf, pa, release := progressProxy(progress, data)
+	defer release()
+
+	histogram := make([]C.GUIntBig, buckets)
+	err := cplCall(func() C.CPLErr {
+		return C.GDALGetRasterHistogramEx(
+			rasterBand.cval,
+			C.double(min),
+			C.double(max),
+			C.int(buckets),
+			&histogram[0],
+			C.int(includeOutOfRange),
+			C.int(approxOK),
+			pf,
+			pa,
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+	counts := make([]int, buckets)
+	for i, count := range histogram {
+		counts[i] = int(count)
+	}
+	return counts, nil
 }
 
 // Return raster unit type
 func (rasterBand RasterBand) GetUnitType() string {
+	rasterBand.check()
 	cString := C.GDALGetRasterUnitType(rasterBand.cval)
 	return C.GoString(cString)
 }
 
 // Set unit type
 func (rasterBand RasterBand) SetUnitType(unit string) error {
+	rasterBand.check()
 	cString := C.CString(unit)
 	defer C.free(unsafe.Pointer(cString))
 
-	return C.GDALSetRasterUnitType(rasterBand.cval, cString).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALSetRasterUnitType(rasterBand.cval, cString)
+	})
 }
 
 // Fetch the raster value offset
 func (rasterBand RasterBand) GetOffset() (float64, bool) {
+	rasterBand.check()
 	var success int
 	val := C.GDALGetRasterOffset(rasterBand.cval, (*C.int)(unsafe.Pointer(&success)))
 	return float64(val), success != 0
@@ -1277,11 +2085,15 @@ func (rasterBand RasterBand) GetOffset() (float64, bool) {
 
 // Set scaling offset
 func (rasterBand RasterBand) SetOffset(offset float64) error {
-	return C.GDALSetRasterOffset(rasterBand.cval, C.double(offset)).Err()
+	rasterBand.check()
+	return cplCall(func() C.CPLErr {
+		return C.GDALSetRasterOffset(rasterBand.cval, C.double(offset))
+	})
 }
 
 // Fetch the raster value scale
 func (rasterBand RasterBand) GetScale() (float64, bool) {
+	rasterBand.check()
 	var success int
 	val := C.GDALGetRasterScale(rasterBand.cval, (*C.int)(unsafe.Pointer(&success)))
 	return float64(val), success != 0
@@ -1289,11 +2101,15 @@ func (rasterBand RasterBand) GetScale() (float64, bool) {
 
 // Set scaling ratio
 func (rasterBand RasterBand) SetScale(scale float64) error {
-	return C.GDALSetRasterScale(rasterBand.cval, C.double(scale)).Err()
+	rasterBand.check()
+	return cplCall(func() C.CPLErr {
+		return C.GDALSetRasterScale(rasterBand.cval, C.double(scale))
+	})
 }
 
 // Compute the min / max values for a band
 func (rasterBand RasterBand) ComputeMinMax(approxOK int) (min, max float64) {
+	rasterBand.check()
 	var minmax [2]float64
 	C.GDALComputeRasterMinMax(
 		rasterBand.cval,
@@ -1304,12 +2120,16 @@ func (rasterBand RasterBand) ComputeMinMax(approxOK int) (min, max float64) {
 
 // Flush raster data cache
 func (rasterBand RasterBand) FlushCache() {
+	rasterBand.check()
 	C.GDALFlushRasterCache(rasterBand.cval)
 }
 
 // Fill this band with a constant value
 func (rasterBand RasterBand) Fill(real, imaginary float64) error {
-	return C.GDALFillRaster(rasterBand.cval, C.double(real), C.double(imaginary)).Err()
+	rasterBand.check()
+	return cplCall(func() C.CPLErr {
+		return C.GDALFillRaster(rasterBand.cval, C.double(real), C.double(imaginary))
+	})
 }
 
 // Unimplemented: ComputeBandStats
@@ -1318,32 +2138,41 @@ func (rasterBand RasterBand) Fill(real, imaginary float64) error {
 
 // Fetch default Raster Attribute Table
 func (rasterBand RasterBand) GetDefaultRAT() RasterAttributeTable {
+	rasterBand.check()
 	rat := C.GDALGetDefaultRAT(rasterBand.cval)
-	return RasterAttributeTable{rat}
+	return RasterAttributeTable{cval: rat}
 }
 
 // Set default Raster Attribute Table
 func (rasterBand RasterBand) SetDefaultRAT(rat RasterAttributeTable) error {
-	return C.GDALSetDefaultRAT(rasterBand.cval, rat.cval).Err()
+	rasterBand.check()
+	return cplCall(func() C.CPLErr {
+		return C.GDALSetDefaultRAT(rasterBand.cval, rat.cval)
+	})
 }
 
 // Unimplemented: AddDerivedBandPixelFunc
 
 // Return the mask band associated with the band
 func (rasterBand RasterBand) GetMaskBand() RasterBand {
+	rasterBand.check()
 	mask := C.GDALGetMaskBand(rasterBand.cval)
-	return RasterBand{mask}
+	return RasterBand{mask, rasterBand.parent}
 }
 
 // Return the status flags of the mask band associated with the band
 func (rasterBand RasterBand) GetMaskFlags() int {
+	rasterBand.check()
 	flags := C.GDALGetMaskFlags(rasterBand.cval)
 	return int(flags)
 }
 
 // Adds a mask band to the current band
 func (rasterBand RasterBand) CreateMaskBand(flags int) error {
-	return C.GDALCreateMaskBand(rasterBand.cval, C.int(flags)).Err()
+	rasterBand.check()
+	return cplCall(func() C.CPLErr {
+		return C.GDALCreateMaskBand(rasterBand.cval, C.int(flags))
+	})
 }
 
 // Copy all raster band raster data
@@ -1353,7 +2182,9 @@ func (sourceRaster RasterBand) RasterBandCopyWholeRaster(
 	progress ProgressFunc,
 	data interface{},
 ) error {
-	arg := &goGDALProgressFuncProxyArgs{progress, data}
+	sourceRaster.check()
+	pf, pa, release := progressProxy(progress, data)
+	defer release()
 
 	length := len(options)
 	cOptions := make([]*C.char, length+1)
@@ -1363,13 +2194,15 @@ func (sourceRaster RasterBand) RasterBandCopyWholeRaster(
 	}
 	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
 
-	return C.GDALRasterBandCopyWholeRaster(
-		sourceRaster.cval,
-		destRaster.cval,
-		(**C.char)(unsafe.Pointer(&cOptions[0])),
-		C.goGDALProgressFuncProxyB(),
-		unsafe.Pointer(arg),
-	).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALRasterBandCopyWholeRaster(
+			sourceRaster.cval,
+			destRaster.cval,
+			(**C.char)(unsafe.Pointer(&cOptions[0])),
+			pf,
+			pa,
+		)
+	})
 }
 
 // Generate downsampled overviews
@@ -1379,9 +2212,96 @@ func (sourceRaster RasterBand) RasterBandCopyWholeRaster(
 /*     GDALAsyncReader                                                  */
 /* ==================================================================== */
 
-// Unimplemented: GetNextUpdatedRegion
-// Unimplemented: LockBuffer
-// Unimplemented: UnlockBuffer
+// Wait up to timeout seconds, or indefinitely if negative, for part of the
+// buffer to be updated, returning the status of the read and the updated
+// region of the buffer
+func (reader AsyncReader) GetNextUpdatedRegion(timeout float64) (AsyncStatusType, image.Rectangle) {
+	reader.check()
+	var xOff, yOff, xSize, ySize C.int
+	status := C.GDALARGetNextUpdatedRegion(reader.cval, C.double(timeout), &xOff, &yOff, &xSize, &ySize)
+	region := image.Rect(int(xOff), int(yOff), int(xOff+xSize), int(yOff+ySize))
+	return AsyncStatusType(status), region
+}
+
+// Lock the buffer against updates, waiting up to timeout seconds, or
+// indefinitely if negative.  Returns false if the lock was not acquired.
+func (reader AsyncReader) LockBuffer(timeout float64) bool {
+	reader.check()
+	return C.GDALARLockBuffer(reader.cval, C.double(timeout)) != 0
+}
+
+// Unlock a buffer locked with LockBuffer
+func (reader AsyncReader) UnlockBuffer() {
+	reader.check()
+	C.GDALARUnlockBuffer(reader.cval)
+}
+
+// End the asynchronous read, after which the buffer may be used freely.
+// Ending a reader more than once is a no-op.
+func (reader AsyncReader) End() {
+	reader.parent.check("AsyncReader")
+	reader.own.close(func() error { return nil })
+}
+
+// Region of the buffer updated by an asynchronous read.  The last update
+// of a failed read has status AR_Error and an empty region, and carries the
+// error in Err.
+type AsyncUpdate struct {
+	Status AsyncStatusType
+	Region image.Rectangle
+	Err    error
+}
+
+// Report updated regions of the buffer on the returned channel until the
+// read completes, fails or ctx is done, then end the reader and close the
+// channel.  The buffer is locked while each update is being sent, so that
+// receivers see consistent pixels, and released before the next one is
+// waited for.  A failed read is reported by a last update with a non-nil
+// Err.  Regions are read on another goroutine, so the dataset must not be
+// used until the channel is closed.
+func (reader AsyncReader) Updates(ctx context.Context) <-chan AsyncUpdate {
+	updates := make(chan AsyncUpdate)
+	go func() {
+		defer close(updates)
+		defer reader.End()
+		fail := func(err error) {
+			select {
+			case updates <- AsyncUpdate{Status: AR_Error, Err: err}:
+			case <-ctx.Done():
+			}
+		}
+
+		for ctx.Err() == nil {
+			var status AsyncStatusType
+			var region image.Rectangle
+			messages := captureErrors(func() {
+				status, region = reader.GetNextUpdatedRegion(0.1)
+			})
+			switch status {
+			case AR_Pending:
+				continue
+			case AR_Error:
+				fail(failure(messages, "Error: asynchronous read failed"))
+				return
+			}
+			if !region.Empty() {
+				if !reader.LockBuffer(-1) {
+					fail(fmt.Errorf("Error: failed to lock the asynchronous read buffer"))
+					return
+				}
+				select {
+				case updates <- AsyncUpdate{Status: status, Region: region}:
+				case <-ctx.Done():
+				}
+				reader.UnlockBuffer()
+			}
+			if status == AR_Complete {
+				return
+			}
+		}
+	}()
+	return updates
+}
 
 /* ==================================================================== */
 /*      Color tables.                                                   */
@@ -1390,18 +2310,38 @@ func (sourceRaster RasterBand) RasterBandCopyWholeRaster(
 // Construct a new color table
 func CreateColorTable(interp PaletteInterp) ColorTable {
 	ct := C.GDALCreateColorTable(C.GDALPaletteInterp(interp))
-	return ColorTable{ct}
+	return ownColorTable(ct)
+}
+
+// Take ownership of a color table handle returned by GDAL
+func ownColorTable(h C.GDALColorTableH) ColorTable {
+	if h == nil {
+		return ColorTable{}
+	}
+	return ColorTable{h, newOwner("ColorTable", func() error { return destroyColorTable(h) })}
+}
+
+func destroyColorTable(h C.GDALColorTableH) error {
+	if h != nil {
+		C.GDALDestroyColorTable(h)
+	}
+	return nil
 }
 
 // Destroy the color table
 func (ct ColorTable) Destroy() {
-	C.GDALDestroyColorTable(ct.cval)
+	ct.Close()
+}
+
+// Destroy the color table.  Closing a color table more than once is a no-op.
+func (ct ColorTable) Close() error {
+	return ct.own.close(func() error { return destroyColorTable(ct.cval) })
 }
 
 // Make a copy of the color table
 func (ct ColorTable) Clone() ColorTable {
 	newCT := C.GDALCloneColorTable(ct.cval)
-	return ColorTable{newCT}
+	return ownColorTable(newCT)
 }
 
 // Fetch palette interpretation
@@ -1473,12 +2413,26 @@ const (
 // Construct empty raster attribute table
 func CreateRasterAttributeTable() RasterAttributeTable {
 	rat := C.GDALCreateRasterAttributeTable()
-	return RasterAttributeTable{rat}
+	return RasterAttributeTable{rat, newOwner("RasterAttributeTable", func() error {
+		return destroyRasterAttributeTable(rat)
+	})}
+}
+
+func destroyRasterAttributeTable(h C.GDALRasterAttributeTableH) error {
+	if h != nil {
+		C.GDALDestroyRasterAttributeTable(h)
+	}
+	return nil
 }
 
 // Destroy a RAT
 func (rat RasterAttributeTable) Destroy() {
-	C.GDALDestroyRasterAttributeTable(rat.cval)
+	rat.Close()
+}
+
+// Destroy a RAT.  Closing a RAT more than once is a no-op.
+func (rat RasterAttributeTable) Close() error {
+	return rat.own.close(func() error { return destroyRasterAttributeTable(rat.cval) })
 }
 
 // Fetch table column count
@@ -1561,12 +2515,16 @@ func (rat RasterAttributeTable) SetRowCount(count int) {
 func (rat RasterAttributeTable) CreateColumn(name string, rft RATFieldType, rfu RATFieldUsage) error {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	return C.GDALRATCreateColumn(rat.cval, cName, C.GDALRATFieldType(rft), C.GDALRATFieldUsage(rfu)).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALRATCreateColumn(rat.cval, cName, C.GDALRATFieldType(rft), C.GDALRATFieldUsage(rfu))
+	})
 }
 
 // Set linear binning information
 func (rat RasterAttributeTable) SetLinearBinning(row0min, binsize float64) error {
-	return C.GDALRATSetLinearBinning(rat.cval, C.double(row0min), C.double(binsize)).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALRATSetLinearBinning(rat.cval, C.double(row0min), C.double(binsize))
+	})
 }
 
 // Fetch linear binning information
@@ -1577,13 +2535,15 @@ func (rat RasterAttributeTable) LinearBinning() (row0min, binsize float64, exist
 
 // Initialize RAT from color table
 func (rat RasterAttributeTable) FromColorTable(ct ColorTable) error {
-	return C.GDALRATInitializeFromColorTable(rat.cval, ct.cval).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALRATInitializeFromColorTable(rat.cval, ct.cval)
+	})
 }
 
 // Translate RAT to a color table
 func (rat RasterAttributeTable) ToColorTable(count int) ColorTable {
 	ct := C.GDALRATTranslateToColorTable(rat.cval, C.int(count))
-	return ColorTable{ct}
+	return ownColorTable(ct)
 }
 
 // Dump RAT in readable form to a file
@@ -1636,7 +2596,9 @@ func setMetadataItem(object unsafe.Pointer, name, value, domain string) error {
 	c_domain := C.CString(domain)
 	defer C.free(unsafe.Pointer(c_domain))
 
-	return C.GDALSetMetadataItem((C.GDALMajorObjectH)(object), c_name, c_value, c_domain).Err()
+	return cplCall(func() C.CPLErr {
+		return C.GDALSetMetadataItem((C.GDALMajorObjectH)(object), c_name, c_value, c_domain)
+	})
 }
 
 func metadata(object unsafe.Pointer, domain string) map[string]string {
@@ -1667,6 +2629,17 @@ func metadata(object unsafe.Pointer, domain string) map[string]string {
 	return metadata
 }
 
+func metadataItem(object unsafe.Pointer, name, domain string) string {
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	c_domain := C.CString(domain)
+	defer C.free(unsafe.Pointer(c_domain))
+
+	cString := C.GDALGetMetadataItem((C.GDALMajorObjectH)(object), c_name, c_domain)
+	return C.GoString(cString)
+}
+
 func description(object unsafe.Pointer) string {
 	cString := C.GDALGetDescription((C.GDALMajorObjectH)(object))
 	return C.GoString(cString)
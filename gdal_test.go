@@ -4,7 +4,19 @@
 
 package gdal
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
 
 func TestTiffDriver(t *testing.T) {
 	_, err := GetDriverByName("GTiff")
@@ -23,7 +35,10 @@ func TestHistogram(t *testing.T) {
 	if err != nil {
 		t.Errorf("%+v", err)
 	}
-	ds := drv.Create("/vsimem/tmp", 10, 10, 1, Byte, nil)
+	ds, err := drv.Create("/vsimem/tmp", 10, 10, 1, Byte, nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
 	defer ds.Close()
 	band := ds.RasterBand(1)
 	data := make([]uint8, 100)
@@ -54,3 +69,1256 @@ func TestConfigOption(t *testing.T) {
 		t.Errorf("Invalid value: %s\n", value)
 	}
 }
+
+func TestOGRErrorCode(t *testing.T) {
+	_, err := CreateFromWKT("POINT (1", SpatialReference{})
+	if err == nil {
+		t.Fatal("expected error parsing truncated WKT")
+	}
+	if !errors.Is(err, OGRERR_CORRUPT_DATA) {
+		t.Errorf("expected OGRERR_CORRUPT_DATA, got: %v", err)
+	}
+	if !errors.Is(err, ErrFailure) {
+		t.Errorf("expected ErrFailure, got: %v", err)
+	}
+	var gdalErr *Error
+	if !errors.As(err, &gdalErr) || gdalErr.Class != CE_Failure {
+		t.Errorf("expected *Error of class CE_Failure, got: %#v", err)
+	}
+}
+
+func TestOpenErrorMessage(t *testing.T) {
+	_, err := Open("/vsimem/does-not-exist.tif", ReadOnly)
+	if err == nil {
+		t.Fatal("expected error opening missing dataset")
+	}
+	var gdalErr *Error
+	if !errors.As(err, &gdalErr) {
+		t.Fatalf("expected *Error, got: %#v", err)
+	}
+	if gdalErr.Num != CPLE_OpenFailed || len(gdalErr.Messages) == 0 {
+		t.Errorf("expected captured CPLE_OpenFailed message, got: %+v", gdalErr)
+	}
+}
+
+func TestLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogHandler(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer SetLogHandler(nil)
+
+	drv, err := GetDriverByName("MEM")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if err := drv.ValidateCreationOptions([]string{"GDAL_GO_NO_SUCH_OPTION=YES"}); err == nil {
+		t.Fatal("expected unknown creation option to be rejected")
+	}
+
+	if !strings.Contains(buf.String(), "level=WARN") || !strings.Contains(buf.String(), "GDAL_GO_NO_SUCH_OPTION") {
+		t.Errorf("expected creation option warning to be logged, got: %q", buf.String())
+	}
+}
+
+func TestCreateCopyWarnings(t *testing.T) {
+	src := memDataset(t, 4, 4, 1, Float32)
+
+	drv, err := GetDriverByName("PNG")
+	if err != nil {
+		t.Skipf("PNG driver not available: %v", err)
+	}
+	// PNG only stores Byte and UInt16, so a non-strict copy warns and
+	// falls back to Byte
+	dst, err := drv.CreateCopy(t.TempDir()+"/warn.png", src, 0, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer dst.Close()
+
+	warnings := dst.Warnings()
+	if len(warnings) == 0 || warnings[0].Class != CE_Warning || !strings.Contains(warnings[0].Msg, "Float32") {
+		t.Errorf("expected data type warning, got: %+v", warnings)
+	}
+	if warnings := src.Warnings(); warnings != nil {
+		t.Errorf("expected no warnings creating the source, got: %+v", warnings)
+	}
+}
+
+func TestProgressClosure(t *testing.T) {
+	drv, err := GetDriverByName("MEM")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	src, err := drv.Create("", 64, 64, 1, Byte, nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer src.Close()
+
+	var last float64
+	progress := func(complete float64, message string, data interface{}) int {
+		last = complete
+		return 1
+	}
+	dst, err := drv.CreateCopy("", src, 0, nil, progress, nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer dst.Close()
+	if last != 1 {
+		t.Errorf("expected progress to reach 1, got: %v", last)
+	}
+}
+
+func TestCreateCopyContext(t *testing.T) {
+	drv, err := GetDriverByName("MEM")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	src, err := drv.Create("", 64, 64, 1, Byte, nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer src.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = drv.CreateCopyContext(ctx, "", src, 0, nil, nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got: %v", err)
+	}
+	if !errors.Is(err, ErrFailure) {
+		t.Errorf("expected ErrFailure, got: %v", err)
+	}
+}
+
+func TestBuildOverviewsContext(t *testing.T) {
+	ds := memDataset(t, 64, 64, 1, Byte)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := ds.BuildOverviewsContext(ctx, "NEAREST", 1, []int{2}, 1, []int{1}, nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got: %v", err)
+	}
+}
+
+func TestScaledProgress(t *testing.T) {
+	var got []float64
+	progress := func(complete float64, message string, data interface{}) int {
+		got = append(got, complete)
+		return 1
+	}
+	outer := CreateScaledProgress(0.5, 1, progress, nil)
+	inner := CreateScaledProgress(0, 0.5, ScaledProgress, outer)
+	ScaledProgress(0, "", inner)
+	ScaledProgress(1, "", inner)
+	ScaledProgress(1, "", outer)
+	expected := []float64{0.5, 0.75, 1}
+	for i := range expected {
+		if i >= len(got) || got[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected ScaledProgress to panic on other progress arguments")
+		}
+	}()
+	ScaledProgress(0.5, "", "not scaled progress")
+}
+
+func TestScaleProgress(t *testing.T) {
+	var got []float64
+	progress := func(complete float64, message string, data interface{}) int {
+		got = append(got, complete)
+		if data != "arg" {
+			t.Errorf("expected progress argument to be passed through, got %v", data)
+		}
+		return 1
+	}
+	inner := ScaleProgress(0, 0.5, ScaleProgress(0.5, 1, progress))
+	inner(0, "", "arg")
+	inner(1, "", "arg")
+	expected := []float64{0.5, 0.75}
+	if len(got) != len(expected) || got[0] != expected[0] || got[1] != expected[1] {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if ScaleProgress(0, 1, nil)(0.5, "", nil) != 1 {
+		t.Error("expected nil progress to continue")
+	}
+}
+
+func TestChannelProgress(t *testing.T) {
+	ch := make(chan ProgressEvent, 1)
+	progress := ChannelProgress(context.Background(), ch)
+	progress(0.25, "a", nil)
+	progress(0.5, "b", nil)
+	if event := <-ch; event.Complete != 0.25 || event.Message != "a" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	progress(1, "done", nil)
+	if event := <-ch; event.Complete != 1 {
+		t.Errorf("expected final event, got: %+v", event)
+	}
+
+	// Nobody reads ch anymore: repeated completion and cancellation must
+	// not block the operation
+	progress(0.5, "c", nil)
+	progress(1, "again", nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	blocked := ChannelProgress(ctx, ch)
+	cancel()
+	if blocked(1, "done", nil) != 0 {
+		t.Error("expected cancelled final update to abort")
+	}
+}
+
+func TestCloseIdempotent(t *testing.T) {
+	drv, err := GetDriverByName("MEM")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	ds, err := drv.Create("", 8, 8, 1, Byte, nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	copied := ds
+	if err := ds.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+	if err := copied.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+
+	geom, err := CreateFromWKT("POINT (1 2)", SpatialReference{})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	geom.Destroy()
+	if err := geom.Close(); err != nil {
+		t.Errorf("Close after Destroy: %v", err)
+	}
+}
+
+func TestSetGeometryDirectlyFailure(t *testing.T) {
+	defn := CreateFeatureDefinition("nogeom")
+	defer defn.Close()
+	defn.SetGeometryType(GT_None)
+	feature := defn.Create()
+	defer feature.Close()
+
+	geom := Create(GT_Point)
+	if err := feature.SetGeometryDirectly(geom); err == nil {
+		t.Fatal("expected error setting geometry without a geometry field")
+	}
+	// The feature deleted the geometry, so closing it must not free it again
+	if err := geom.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+}
+
+func TestAddGeometryDirectlyFailure(t *testing.T) {
+	multipoint := Create(GT_MultiPoint)
+	defer multipoint.Close()
+
+	polygon := Create(GT_Polygon)
+	if err := multipoint.AddGeometryDirectly(polygon); err == nil {
+		t.Fatal("expected error adding a polygon to a multipoint")
+	}
+	// The container deleted the geometry, so closing it must not free it again
+	if err := polygon.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+}
+
+func TestSpatialReferenceRelease(t *testing.T) {
+	sr := CreateSpatialReference("")
+	sr.Reference()
+	sr.Reference()
+	sr.Release()
+	sr.Release()
+	if count := sr.Reference(); count != 2 {
+		t.Errorf("expected each Release to drop a reference, got count %d", count)
+	}
+	sr.Dereference()
+	if err := sr.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+}
+
+func TestUseAfterClose(t *testing.T) {
+	if !debugHandles {
+		t.Skip("use-after-close checks require the gdaldebug build tag")
+	}
+	drv, err := GetDriverByName("MEM")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	ds, err := drv.Create("", 8, 8, 1, Byte, nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	band := ds.RasterBand(1)
+	ds.Close()
+
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, "RasterBand used after its Dataset was closed") {
+			t.Errorf("unexpected panic: %q", msg)
+		}
+	}()
+	band.XSize()
+	t.Error("expected panic")
+}
+
+// Create a MEM dataset closed when the test ends
+func memDataset(t *testing.T, xSize, ySize, bands int, dataType DataType) Dataset {
+	t.Helper()
+	drv, err := GetDriverByName("MEM")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	ds, err := drv.Create("", xSize, ySize, bands, dataType, nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	t.Cleanup(func() { ds.Close() })
+	return ds
+}
+
+func TestReadWriteWindow(t *testing.T) {
+	ds := memDataset(t, 4, 3, 1, CInt16)
+	band := ds.RasterBand(1)
+
+	pixels := make([]ComplexInt16, 4*3)
+	for i := range pixels {
+		pixels[i] = ComplexInt16{int16(i), int16(-i)}
+	}
+	if err := WriteWindow(band, 0, 0, 4, 3, pixels); err != nil {
+		t.Fatalf("WriteWindow: %v", err)
+	}
+	got, err := ReadWindow[ComplexInt16](band, 1, 1, 2, 2)
+	if err != nil {
+		t.Fatalf("ReadWindow: %v", err)
+	}
+	if got[0] != (ComplexInt16{5, -5}) || got[3] != (ComplexInt16{10, -10}) {
+		t.Errorf("unexpected pixels: %v", got)
+	}
+
+	if err := WriteWindow(band, 0, 0, 4, 3, pixels[:5]); err == nil {
+		t.Error("expected error writing undersized buffer")
+	}
+	if err := RasterIO(band, Read, 0, 0, 4, 3, []float64{}, 4, 3, 0, 0); err == nil {
+		t.Error("expected error reading into empty buffer")
+	}
+}
+
+func TestIOValidation(t *testing.T) {
+	ds := memDataset(t, 4, 4, 2, Byte)
+
+	buffer := make([]uint8, 4*4*2)
+	if err := ds.IO(Read, 0, 0, 4, 4, buffer, 4, 4, 0, nil, 0, 0, 0); err != nil {
+		t.Errorf("IO with nil band map: %v", err)
+	}
+	if err := ds.IO(Read, 0, 0, 4, 4, buffer[:31], 4, 4, 0, nil, 0, 0, 0); err == nil {
+		t.Error("expected error for undersized buffer")
+	}
+	if err := ds.IO(Read, 0, 0, 4, 4, buffer, 4, 4, 1, []int{3}, 0, 0, 0); err == nil {
+		t.Error("expected error for out of range band")
+	}
+	if err := ds.IO(Read, 0, 0, 4, 4, buffer, 4, 4, -1, nil, 0, 0, 0); err == nil {
+		t.Error("expected error for negative band count")
+	}
+	if err := ds.IO(Read, 2, 2, 4, 4, buffer, 4, 4, 0, nil, 0, 0, 0); err == nil {
+		t.Error("expected error for window outside raster")
+	}
+	if err := ds.RasterBand(1).IO(Read, 0, 0, 4, 4, []uint8{}, 4, 4, 0, 0); err == nil {
+		t.Error("expected error for empty buffer")
+	}
+}
+
+func TestImage(t *testing.T) {
+	drv, err := GetDriverByName("MEM")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	ds, err := drv.Create("", 3, 2, 3, Byte, nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer ds.Close()
+	for i, value := range []uint8{10, 20, 30} {
+		pixels := []uint8{0, 0, 0, 0, 0, value}
+		if err := WriteWindow(ds.RasterBand(i+1), 0, 0, 3, 2, pixels); err != nil {
+			t.Fatalf("WriteWindow: %v", err)
+		}
+	}
+
+	img, err := ds.Image()
+	if err != nil {
+		t.Fatalf("Image: %v", err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 3, 2) {
+		t.Errorf("unexpected bounds: %v", img.Bounds())
+	}
+	if c := img.At(2, 1); c != (color.RGBA{10, 20, 30, 255}) {
+		t.Errorf("unexpected color: %v", c)
+	}
+	if err := img.Err(); err != nil {
+		t.Errorf("Err: %v", err)
+	}
+
+	band := ds.RasterBand(1)
+	ct := CreateColorTable(PI_RGB)
+	defer ct.Close()
+	if err := band.SetColorTable(ct); err != nil {
+		t.Fatalf("SetColorTable: %v", err)
+	}
+	if err := band.SetColorInterp(CI_PaletteIndex); err != nil {
+		t.Fatalf("SetColorInterp: %v", err)
+	}
+	paletted, err := band.Image()
+	if err != nil {
+		t.Fatalf("Image: %v", err)
+	}
+	if _, ok := paletted.ColorModel().(color.Palette); !ok {
+		t.Errorf("expected palette color model, got %T", paletted.ColorModel())
+	}
+	if i := paletted.ColorIndexAt(2, 1); i != 10 {
+		t.Errorf("unexpected index: %d", i)
+	}
+
+	deep, err := drv.Create("", 2, 1, 1, UInt16, nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer deep.Close()
+	if err := WriteWindow(deep.RasterBand(1), 0, 0, 2, 1, []uint16{0, 1000}); err != nil {
+		t.Fatalf("WriteWindow: %v", err)
+	}
+	gray, err := deep.Image()
+	if err != nil {
+		t.Fatalf("Image: %v", err)
+	}
+	if c := gray.At(1, 0); c != (color.Gray16{1000}) {
+		t.Errorf("unexpected color: %v", c)
+	}
+
+	signed, err := drv.Create("", 2, 1, 1, Int16, nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer signed.Close()
+	if _, err := signed.Image(); err == nil {
+		t.Error("expected Int16 bands to be rejected")
+	}
+
+	mixed, err := drv.Create("", 2, 1, 1, Byte, nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer mixed.Close()
+	if err := mixed.AddBand(UInt16, nil); err != nil {
+		t.Fatalf("AddBand: %v", err)
+	}
+	if _, err := mixed.Image(); err == nil {
+		t.Error("expected mixed Byte and UInt16 bands to be rejected")
+	}
+}
+
+func TestCreateFromImage(t *testing.T) {
+	drv, err := GetDriverByName("MEM")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	src.Set(1, 1, color.NRGBA{1, 2, 3, 128})
+	ds, err := drv.CreateFromImage("", src, [6]float64{0, 1, 0, 0, 0, -1}, "", nil)
+	if err != nil {
+		t.Fatalf("CreateFromImage: %v", err)
+	}
+	defer ds.Close()
+	if ds.RasterCount() != 4 || ds.RasterBand(4).ColorInterp() != CI_AlphaBand {
+		t.Errorf("expected RGBA bands, got %d bands", ds.RasterCount())
+	}
+	img, err := ds.Image()
+	if err != nil {
+		t.Fatalf("Image: %v", err)
+	}
+	if c := img.At(1, 1); c != (color.NRGBA{1, 2, 3, 128}) {
+		t.Errorf("unexpected color: %v", c)
+	}
+
+	palette := color.Palette{color.Black, color.White}
+	pal := image.NewPaletted(image.Rect(0, 0, 2, 1), palette)
+	pal.SetColorIndex(1, 0, 1)
+	pds, err := drv.CreateFromImage("", pal, [6]float64{}, "", nil)
+	if err != nil {
+		t.Fatalf("CreateFromImage: %v", err)
+	}
+	defer pds.Close()
+	band := pds.RasterBand(1)
+	if band.ColorInterp() != CI_PaletteIndex || band.ColorTable().EntryCount() != 2 {
+		t.Error("expected a palette band with two entries")
+	}
+}
+
+func TestCreateFromImagePNG(t *testing.T) {
+	drv, err := GetDriverByName("PNG")
+	if err != nil {
+		t.Skipf("PNG driver not available: %v", err)
+	}
+	if drv.Capabilities().Create {
+		t.Fatal("expected PNG to only support CreateCopy")
+	}
+
+	src := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	src.Set(2, 1, color.NRGBA{10, 20, 30, 200})
+	filename := t.TempDir() + "/image.png"
+	gt := [6]float64{100, 1, 0, 200, 0, -1}
+	ds, err := drv.CreateFromImage(filename, src, gt, "", nil)
+	if err != nil {
+		t.Fatalf("CreateFromImage: %v", err)
+	}
+	if err := ds.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	ds, err = Open(filename, ReadOnly)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer ds.Close()
+	if ds.RasterCount() != 4 || ds.RasterXSize() != 3 || ds.RasterYSize() != 2 {
+		t.Fatalf("unexpected dataset %dx%dx%d", ds.RasterXSize(), ds.RasterYSize(), ds.RasterCount())
+	}
+	if got := ds.GeoTransform(); got != gt {
+		t.Errorf("expected geotransform %v, got %v", gt, got)
+	}
+	img, err := ds.Image()
+	if err != nil {
+		t.Fatalf("Image: %v", err)
+	}
+	if c := img.At(2, 1); c != (color.NRGBA{10, 20, 30, 200}) {
+		t.Errorf("unexpected color: %v", c)
+	}
+	if c := img.At(0, 0); c != (color.NRGBA{}) {
+		t.Errorf("expected transparent pixel, got %v", c)
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	ds := memDataset(t, 5, 3, 1, Int16)
+	band := ds.RasterBand(1)
+
+	pixels := 0
+	for block, err := range Blocks[float64](band, Update) {
+		if err != nil {
+			t.Fatalf("Blocks: %v", err)
+		}
+		if len(block.Data) != block.Window.Dx()*block.Window.Dy() {
+			t.Errorf("block %v has %d pixels", block.Window, len(block.Data))
+		}
+		for i := range block.Data {
+			block.Data[i] = 7
+		}
+		pixels += len(block.Data)
+	}
+	if pixels != 5*3 {
+		t.Errorf("expected 15 pixels, got %d", pixels)
+	}
+
+	got, err := ReadWindow[int16](band, 0, 0, 5, 3)
+	if err != nil {
+		t.Fatalf("ReadWindow: %v", err)
+	}
+	for _, v := range got {
+		if v != 7 {
+			t.Fatalf("expected blocks to be written back, got %v", got)
+		}
+	}
+}
+
+func TestBlocksEdges(t *testing.T) {
+	drv, err := GetDriverByName("GTiff")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	ds, err := drv.Create(t.TempDir()+"/tiled.tif", 300, 300, 1, Byte, []string{"TILED=YES", "BLOCKXSIZE=256", "BLOCKYSIZE=256"})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer ds.Close()
+	band := ds.RasterBand(1)
+
+	var windows []image.Rectangle
+	for block, err := range Blocks[uint8](band, Update) {
+		if err != nil {
+			t.Fatalf("Blocks: %v", err)
+		}
+		if len(block.Data) != block.Window.Dx()*block.Window.Dy() {
+			t.Errorf("block %v has %d pixels", block.Window, len(block.Data))
+		}
+		for i := range block.Data {
+			block.Data[i] = uint8(1 + block.XBlock + 2*block.YBlock)
+		}
+		windows = append(windows, block.Window)
+	}
+	expected := []image.Rectangle{
+		image.Rect(0, 0, 256, 256),
+		image.Rect(256, 0, 300, 256),
+		image.Rect(0, 256, 256, 300),
+		image.Rect(256, 256, 300, 300),
+	}
+	if len(windows) != len(expected) {
+		t.Fatalf("expected windows %v, got %v", expected, windows)
+	}
+	for i := range expected {
+		if windows[i] != expected[i] {
+			t.Errorf("expected window %v, got %v", expected[i], windows[i])
+		}
+	}
+
+	for _, p := range []struct {
+		x, y  int
+		value uint8
+	}{{0, 0, 1}, {299, 0, 2}, {0, 299, 3}, {299, 299, 4}, {255, 256, 3}} {
+		got, err := ReadWindow[uint8](band, p.x, p.y, 1, 1)
+		if err != nil {
+			t.Fatalf("ReadWindow: %v", err)
+		}
+		if got[0] != p.value {
+			t.Errorf("pixel %d,%d: expected %d, got %d", p.x, p.y, p.value, got[0])
+		}
+	}
+}
+
+func TestProcessBlocks(t *testing.T) {
+	drv, err := GetDriverByName("GTiff")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	filename := t.TempDir() + "/input.tif"
+	src, err := drv.Create(filename, 64, 64, 2, Byte, []string{"TILED=YES", "BLOCKXSIZE=16", "BLOCKYSIZE=16"})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	pixels := make([]uint8, 64*64)
+	for i := range pixels {
+		pixels[i] = 3
+	}
+	for band := 1; band <= 2; band++ {
+		if err := WriteWindow(src.RasterBand(band), 0, 0, 64, 64, pixels); err != nil {
+			t.Fatalf("WriteWindow: %v", err)
+		}
+	}
+	if err := src.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	dst := memDataset(t, 64, 64, 1, Float32)
+
+	inputs := []BandInput{{filename, 1}, {filename, 2}}
+	var events []float64
+	options := ProcessOptions{
+		Workers: 4,
+		Progress: func(complete float64, message string, data interface{}) int {
+			events = append(events, complete)
+			return 1
+		},
+	}
+	err = ProcessBlocks(context.Background(), inputs, dst.RasterBand(1),
+		func(in [][]float32, out []float32, window image.Rectangle) error {
+			for i := range out {
+				out[i] = in[0][i] * in[1][i]
+			}
+			return nil
+		}, options)
+	if err != nil {
+		t.Fatalf("ProcessBlocks: %v", err)
+	}
+	if len(events) == 0 || events[len(events)-1] != 1 {
+		t.Errorf("unexpected progress: %v", events)
+	}
+	got, err := ReadWindow[float32](dst.RasterBand(1), 0, 0, 64, 64)
+	if err != nil {
+		t.Fatalf("ReadWindow: %v", err)
+	}
+	for _, v := range got {
+		if v != 9 {
+			t.Fatalf("unexpected output pixel %v", v)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = ProcessBlocks(ctx, inputs, dst.RasterBand(1),
+		func(in [][]float32, out []float32, window image.Rectangle) error { return nil },
+		ProcessOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got: %v", err)
+	}
+}
+
+func TestNewMemDataset(t *testing.T) {
+	data := []float32{1, 2, 3, 4, 5, 6}
+	ds, err := NewMemDataset(3, 2, [][]float32{data}, [6]float64{}, "")
+	if err != nil {
+		t.Fatalf("NewMemDataset: %v", err)
+	}
+	got, err := ReadWindow[float32](ds.RasterBand(1), 2, 1, 1, 1)
+	if err != nil {
+		t.Fatalf("ReadWindow: %v", err)
+	}
+	if got[0] != 6 {
+		t.Errorf("expected 6, got %v", got[0])
+	}
+	if err := WriteWindow(ds.RasterBand(1), 0, 0, 1, 1, []float32{42}); err != nil {
+		t.Fatalf("WriteWindow: %v", err)
+	}
+	if err := ds.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if data[0] != 42 {
+		t.Errorf("expected write through to Go slice, got %v", data[0])
+	}
+
+	if _, err := NewMemDataset(3, 3, [][]float32{data}, [6]float64{}, ""); err == nil {
+		t.Error("expected error for undersized band")
+	}
+
+	alloc, bands, err := AllocMemDataset[uint16](2, 2, 2, [6]float64{}, "")
+	if err != nil {
+		t.Fatalf("AllocMemDataset: %v", err)
+	}
+	defer alloc.Close()
+	bands[1][3] = 9
+	got16, err := ReadWindow[uint16](alloc.RasterBand(2), 1, 1, 1, 1)
+	if err != nil {
+		t.Fatalf("ReadWindow: %v", err)
+	}
+	if got16[0] != 9 {
+		t.Errorf("expected 9, got %v", got16[0])
+	}
+}
+
+func TestAsyncReader(t *testing.T) {
+	data := []uint8{1, 2, 3, 4}
+	ds, err := NewMemDataset(2, 2, [][]uint8{data}, [6]float64{}, "")
+	if err != nil {
+		t.Fatalf("NewMemDataset: %v", err)
+	}
+	defer ds.Close()
+
+	buffer := make([]uint8, 4)
+	reader, err := ds.BeginAsyncReader(0, 0, 2, 2, buffer, 2, 2, 0, nil, 0, 0, 0, nil)
+	if err != nil {
+		t.Fatalf("BeginAsyncReader: %v", err)
+	}
+	var last AsyncUpdate
+	for update := range reader.Updates(context.Background()) {
+		last = update
+	}
+	if last.Status != AR_Complete || last.Region != image.Rect(0, 0, 2, 2) {
+		t.Errorf("unexpected final update: %+v", last)
+	}
+	if buffer[3] != 4 {
+		t.Errorf("unexpected buffer: %v", buffer)
+	}
+	reader.End()
+}
+
+func TestAsyncReaderError(t *testing.T) {
+	// A 2x2 TIFF whose only strip lies past the end of the file, so that
+	// it opens but cannot be read
+	var tiff bytes.Buffer
+	tiff.WriteString("II*\x00")
+	binary.Write(&tiff, binary.LittleEndian, uint32(8))
+	entries := [][3]uint32{
+		{256, 3, 2},    // ImageWidth
+		{257, 3, 2},    // ImageLength
+		{258, 3, 8},    // BitsPerSample
+		{259, 3, 1},    // Compression
+		{262, 3, 1},    // PhotometricInterpretation
+		{273, 4, 4096}, // StripOffsets
+		{278, 3, 2},    // RowsPerStrip
+		{279, 4, 4},    // StripByteCounts
+	}
+	binary.Write(&tiff, binary.LittleEndian, uint16(len(entries)))
+	for _, entry := range entries {
+		binary.Write(&tiff, binary.LittleEndian, uint16(entry[0]))
+		binary.Write(&tiff, binary.LittleEndian, uint16(entry[1]))
+		binary.Write(&tiff, binary.LittleEndian, uint32(1))
+		binary.Write(&tiff, binary.LittleEndian, entry[2])
+	}
+	binary.Write(&tiff, binary.LittleEndian, uint32(0))
+	filename := t.TempDir() + "/truncated.tif"
+	if err := os.WriteFile(filename, tiff.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ds, err := Open(filename, ReadOnly)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer ds.Close()
+	buffer := make([]uint8, 4)
+	reader, err := ds.BeginAsyncReader(0, 0, 2, 2, buffer, 2, 2, 0, nil, 0, 0, 0, nil)
+	if err != nil {
+		t.Fatalf("BeginAsyncReader: %v", err)
+	}
+	var last AsyncUpdate
+	for update := range reader.Updates(context.Background()) {
+		last = update
+	}
+	if last.Status != AR_Error || last.Err == nil {
+		t.Errorf("expected a failed read to report an error, got %+v", last)
+	}
+}
+
+func TestGCPs(t *testing.T) {
+	ds := memDataset(t, 10, 10, 1, Byte)
+
+	gcps := []GCP{
+		{ID: "1", Pixel: 0, Line: 0, X: 100, Y: 200},
+		{ID: "2", Pixel: 10, Line: 0, X: 110, Y: 200},
+		{ID: "3", Pixel: 0, Line: 10, X: 100, Y: 190},
+	}
+	srs := CreateSpatialReference("")
+	defer srs.Close()
+	if err := srs.FromEPSG(4326); err != nil {
+		t.Fatalf("FromEPSG: %v", err)
+	}
+	wkt, err := srs.ToWKT()
+	if err != nil {
+		t.Fatalf("ToWKT: %v", err)
+	}
+	if err := ds.SetGCPs(gcps, wkt); err != nil {
+		t.Fatalf("SetGCPs: %v", err)
+	}
+	got := ds.GCPs()
+	if len(got) != 3 || got[1] != gcps[1] {
+		t.Errorf("unexpected GCPs: %+v", got)
+	}
+	if ds.GCPProjection() == "" {
+		t.Error("expected a GCP projection")
+	}
+
+	transform, ok := GCPsToGeoTransform(gcps, false)
+	if !ok {
+		t.Fatal("GCPsToGeoTransform failed")
+	}
+	for i, want := range [6]float64{100, 1, 0, 200, 0, -1} {
+		if diff := transform[i] - want; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("unexpected transform %v", transform)
+			break
+		}
+	}
+}
+
+func TestOpenEx(t *testing.T) {
+	filename := t.TempDir() + "/points.geojson"
+	geojson := `{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "properties": {}, "geometry": {"type": "Point", "coordinates": [1, 2]}}
+	]}`
+	if err := os.WriteFile(filename, []byte(geojson), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ds, err := OpenEx(filename, OpenOptions{Vector: true, AllowedDrivers: []string{"GeoJSON"}})
+	if err != nil {
+		t.Fatalf("OpenEx: %v", err)
+	}
+	defer ds.Close()
+	if ds.LayerCount() != 1 {
+		t.Fatalf("expected 1 layer, got %d", ds.LayerCount())
+	}
+	if count, ok := ds.LayerByIndex(0).FeatureCount(true); !ok || count != 1 {
+		t.Errorf("expected 1 feature, got %d", count)
+	}
+
+	_, err = OpenEx(filename, OpenOptions{Raster: true})
+	if err == nil {
+		t.Error("expected error opening vector file as raster")
+	}
+}
+
+func TestCreateSkipsGDALOptionValidation(t *testing.T) {
+	const key = "GDAL_VALIDATE_CREATION_OPTIONS"
+	var during, after string
+	captureErrors(func() {
+		withoutCreationOptionValidation(func() {
+			during = GetConfigOption(key, "YES")
+		})
+		after = GetConfigOption(key, "YES")
+	})
+	if during != "NO" {
+		t.Errorf("expected GDAL validation to be disabled during the call, got %s=%s", key, during)
+	}
+	if after != "YES" {
+		t.Errorf("expected %s to be restored, got %s", key, after)
+	}
+}
+
+func TestDriverMetadata(t *testing.T) {
+	drv, err := GetDriverByName("GTiff")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	capabilities := drv.Capabilities()
+	if !capabilities.Create || !capabilities.Raster {
+		t.Errorf("unexpected capabilities: %+v", capabilities)
+	}
+
+	options, err := drv.CreationOptions()
+	if err != nil {
+		t.Fatalf("CreationOptions: %v", err)
+	}
+	var compress *CreationOption
+	for i := range options {
+		if options[i].Name == "COMPRESS" {
+			compress = &options[i]
+		}
+	}
+	if compress == nil || len(compress.Values) == 0 {
+		t.Errorf("expected COMPRESS option with values, got %+v", compress)
+	}
+
+	found := false
+	for _, ext := range drv.Extensions() {
+		found = found || ext == "tif"
+	}
+	if !found {
+		t.Errorf("expected tif extension, got %v", drv.Extensions())
+	}
+	if len(drv.CreationDataTypes()) == 0 {
+		t.Error("expected creation data types")
+	}
+
+	_, err = drv.Create(t.TempDir()+"/bad.tif", 1, 1, 1, Byte, []string{"COMPRESS=NO_SUCH_CODEC"})
+	if !errors.Is(err, ErrFailure) || !strings.Contains(err.Error(), "COMPRESS") {
+		t.Errorf("expected creation option error, got: %v", err)
+	}
+}
+
+func TestDatasetInfo(t *testing.T) {
+	data := []uint8{1, 2, 3, 4}
+	ds, err := NewMemDataset(2, 2, [][]uint8{data}, [6]float64{100, 1, 0, 200, 0, -1}, "")
+	if err != nil {
+		t.Fatalf("NewMemDataset: %v", err)
+	}
+	defer ds.Close()
+	if err := ds.RasterBand(1).SetNoDataValue(0); err != nil {
+		t.Fatalf("SetNoDataValue: %v", err)
+	}
+
+	info, err := ds.Info(InfoOptions{Stats: true})
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	if info.DriverShortName != "MEM" || info.Size != [2]int{2, 2} {
+		t.Errorf("unexpected info: %+v", info)
+	}
+	if len(info.GeoTransform) != 6 || info.GeoTransform[0] != 100 {
+		t.Errorf("unexpected geotransform: %v", info.GeoTransform)
+	}
+	if len(info.Bands) != 1 {
+		t.Fatalf("expected 1 band, got %d", len(info.Bands))
+	}
+	band := info.Bands[0]
+	if band.Type != "Byte" || band.NoDataValue == nil || *band.NoDataValue != 0 {
+		t.Errorf("unexpected band info: %+v", band)
+	}
+	if band.Maximum == nil || *band.Maximum != 4 {
+		t.Errorf("expected statistics, got %+v", band)
+	}
+}
+
+func TestDataSourceInfo(t *testing.T) {
+	filename := t.TempDir() + "/places.geojson"
+	geojson := `{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "properties": {"name": "a", "rank": 1}, "geometry": {"type": "Point", "coordinates": [1, 2]}},
+		{"type": "Feature", "properties": {"name": "b", "rank": 2}, "geometry": {"type": "Point", "coordinates": [3, 4]}}
+	]}`
+	if err := os.WriteFile(filename, []byte(geojson), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ds, err := OpenDataSource(filename, 0)
+	if err != nil {
+		t.Fatalf("OpenDataSource: %v", err)
+	}
+	defer ds.Close()
+	info, err := ds.Info(VectorInfoOptions{Features: 1})
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	if info.DriverShortName != "GeoJSON" || len(info.Layers) != 1 {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+	layer := info.Layers[0]
+	if layer.FeatureCount != 2 || len(layer.Fields) != 2 || len(layer.GeometryFields) != 1 {
+		t.Fatalf("unexpected layer info: %+v", layer)
+	}
+	if layer.Fields[0].Name != "name" || layer.Fields[0].Type != "String" {
+		t.Errorf("unexpected field info: %+v", layer.Fields[0])
+	}
+	if extent := layer.GeometryFields[0].Extent; len(extent) != 4 || extent[2] != 3 {
+		t.Errorf("unexpected extent: %v", extent)
+	}
+	if len(layer.Features) != 1 || layer.Features[0].Properties["name"] != "a" {
+		t.Errorf("unexpected features: %+v", layer.Features)
+	}
+
+	if _, err := ds.Info(VectorInfoOptions{Layers: []string{"missing"}}); err == nil {
+		t.Error("expected error for missing layer")
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	data := []uint8{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+		13, 14, 15, 16,
+	}
+	src, err := NewMemDataset(4, 4, [][]uint8{data, data}, [6]float64{100, 1, 0, 200, 0, -1}, "")
+	if err != nil {
+		t.Fatalf("NewMemDataset: %v", err)
+	}
+	defer src.Close()
+	if err := src.SetMetadataItem("KEY", "value", ""); err != nil {
+		t.Fatalf("SetMetadataItem: %v", err)
+	}
+
+	noData := -1.0
+	dst, err := Translate("", src, TranslateOptions{
+		Format:        "MEM",
+		OutputType:    Float32,
+		Bands:         []int{2},
+		ProjWindow:    &[4]float64{101, 199, 103, 197},
+		NoData:        &noData,
+		StripMetadata: true,
+	})
+	if err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+	defer dst.Close()
+	if dst.RasterXSize() != 2 || dst.RasterYSize() != 2 || dst.RasterCount() != 1 {
+		t.Fatalf("unexpected output size %dx%dx%d", dst.RasterXSize(), dst.RasterYSize(), dst.RasterCount())
+	}
+	band := dst.RasterBand(1)
+	if band.RasterDataType() != Float32 {
+		t.Errorf("expected Float32, got %s", band.RasterDataType().Name())
+	}
+	if value, ok := band.NoDataValue(); !ok || value != -1 {
+		t.Errorf("expected nodata -1, got %v", value)
+	}
+	if gt := dst.GeoTransform(); gt[0] != 101 || gt[3] != 199 {
+		t.Errorf("unexpected geotransform: %v", gt)
+	}
+	if len(dst.Metadata("")) != 0 {
+		t.Errorf("expected no metadata, got %v", dst.Metadata(""))
+	}
+	pixels, err := ReadWindow[float32](band, 0, 0, 2, 2)
+	if err != nil {
+		t.Fatalf("ReadWindow: %v", err)
+	}
+	if pixels[0] != 6 || pixels[3] != 11 {
+		t.Errorf("unexpected pixels: %v", pixels)
+	}
+
+	if _, err := Translate("", src, TranslateOptions{Format: "MEM", Exponent: 2}); err == nil {
+		t.Error("expected error for Exponent without Scale")
+	}
+	scale := &TranslateScale{DstMin: 0, DstMax: 1}
+	if _, err := Translate("", src, TranslateOptions{Format: "MEM", Scale: scale}); err == nil {
+		t.Error("expected error for destination range without source range")
+	}
+}
+
+func TestWarp(t *testing.T) {
+	sr := CreateSpatialReference("")
+	defer sr.Close()
+	if err := sr.FromEPSG(4326); err != nil {
+		t.Fatalf("FromEPSG: %v", err)
+	}
+	wkt, err := sr.ToWKT()
+	if err != nil {
+		t.Fatalf("ToWKT: %v", err)
+	}
+
+	left, err := NewMemDataset(2, 2, [][]uint8{{1, 1, 1, 1}}, [6]float64{0, 1, 0, 2, 0, -1}, wkt)
+	if err != nil {
+		t.Fatalf("NewMemDataset: %v", err)
+	}
+	defer left.Close()
+	right, err := NewMemDataset(2, 2, [][]uint8{{2, 2, 2, 2}}, [6]float64{2, 1, 0, 2, 0, -1}, wkt)
+	if err != nil {
+		t.Fatalf("NewMemDataset: %v", err)
+	}
+	defer right.Close()
+
+	dst, err := Warp("", []Dataset{left, right}, WarpOpts{
+		Format:    "MEM",
+		XRes:      1,
+		YRes:      1,
+		DstNoData: []float64{0},
+	})
+	if err != nil {
+		t.Fatalf("Warp: %v", err)
+	}
+	defer dst.Close()
+	if dst.RasterXSize() != 4 || dst.RasterYSize() != 2 {
+		t.Fatalf("expected 4x2 mosaic, got %dx%d", dst.RasterXSize(), dst.RasterYSize())
+	}
+	pixels, err := ReadWindow[uint8](dst.RasterBand(1), 0, 0, 4, 1)
+	if err != nil {
+		t.Fatalf("ReadWindow: %v", err)
+	}
+	if string(pixels) != string([]uint8{1, 1, 2, 2}) {
+		t.Errorf("unexpected mosaic row: %v", pixels)
+	}
+
+	if _, err := Warp("", nil, WarpOpts{}); err == nil {
+		t.Error("expected error warping no sources")
+	}
+	if _, err := Warp("", []Dataset{left}, WarpOpts{Format: "MEM", XRes: 1}); err == nil {
+		t.Error("expected error for XRes without YRes")
+	}
+
+	args, err := WarpOpts{Threads: 2}.args()
+	if err != nil {
+		t.Fatalf("args: %v", err)
+	}
+	if !strings.Contains(strings.Join(args, " "), "-wo NUM_THREADS=2") {
+		t.Errorf("expected Threads to set NUM_THREADS, got %v", args)
+	}
+}
+
+func TestVectorTranslate(t *testing.T) {
+	dir := t.TempDir()
+	geojson := `{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "properties": {"name": "a", "rank": 1}, "geometry": {"type": "Point", "coordinates": [1, 2]}},
+		{"type": "Feature", "properties": {"name": "b", "rank": 2}, "geometry": {"type": "Point", "coordinates": [3, 4]}}
+	]}`
+	if err := os.WriteFile(dir+"/src.geojson", []byte(geojson), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	src, err := OpenDataSource(dir+"/src.geojson", 0)
+	if err != nil {
+		t.Fatalf("OpenDataSource: %v", err)
+	}
+	defer src.Close()
+
+	dst, err := VectorTranslate(dir+"/dst.geojson", src, VectorTranslateOptions{
+		Format:       "GeoJSON",
+		LayerName:    "renamed",
+		Where:        "rank > 1",
+		Fields:       []string{"name"},
+		GeometryType: "PROMOTE_TO_MULTI",
+	})
+	if err != nil {
+		t.Fatalf("VectorTranslate: %v", err)
+	}
+	if err := dst.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	out, err := OpenDataSource(dir+"/dst.geojson", 0)
+	if err != nil {
+		t.Fatalf("OpenDataSource: %v", err)
+	}
+	defer out.Close()
+	layer := out.LayerByIndex(0)
+	if layer.Name() != "renamed" {
+		t.Errorf("expected layer renamed, got %s", layer.Name())
+	}
+	if count, _ := layer.FeatureCount(true); count != 1 {
+		t.Errorf("expected 1 feature, got %d", count)
+	}
+	if count := layer.Definition().FieldCount(); count != 1 {
+		t.Errorf("expected 1 field, got %d", count)
+	}
+	if geomType := layer.Definition().GeometryType(); geomType != GT_MultiPoint {
+		t.Errorf("expected MultiPoint, got %s", geomType.Name())
+	}
+}
+
+func TestVRTDataset(t *testing.T) {
+	drv, err := GetDriverByName("GTiff")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	dir := t.TempDir()
+	for i, value := range []uint8{1, 2} {
+		filename := fmt.Sprintf("%s/tile%d.tif", dir, i)
+		tile, err := drv.Create(filename, 2, 2, 1, Byte, nil)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		if err := WriteWindow(tile.RasterBand(1), 0, 0, 2, 2, []uint8{value, value, value, value}); err != nil {
+			t.Fatalf("WriteWindow: %v", err)
+		}
+		if err := tile.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+	}
+
+	vrt := NewVRTDataset(4, 2)
+	vrt.GeoTransform = [6]float64{0, 1, 0, 2, 0, -1}
+	band := vrt.AddBand(Byte)
+	band.AddSource(VRTSource{
+		Filename:  dir + "/tile0.tif",
+		Band:      1,
+		DstWindow: image.Rect(0, 0, 2, 2),
+	})
+	band.AddSource(VRTSource{
+		Kind:        VRTComplexSource,
+		Filename:    dir + "/tile1.tif",
+		Band:        1,
+		DstWindow:   image.Rect(2, 0, 4, 2),
+		ScaleRatio:  10,
+		ScaleOffset: 1,
+	})
+
+	doc, err := vrt.XML()
+	if err != nil {
+		t.Fatalf("XML: %v", err)
+	}
+	if !strings.Contains(doc, "<ComplexSource>") || !strings.Contains(doc, "<ScaleRatio>10</ScaleRatio>") {
+		t.Errorf("unexpected VRT XML:\n%s", doc)
+	}
+
+	ds, err := vrt.Open()
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer ds.Close()
+	pixels, err := ReadWindow[uint8](ds.RasterBand(1), 0, 0, 4, 1)
+	if err != nil {
+		t.Fatalf("ReadWindow: %v", err)
+	}
+	if string(pixels) != string([]uint8{1, 1, 21, 21}) {
+		t.Errorf("unexpected pixels: %v", pixels)
+	}
+
+	for _, invalid := range []VRTSource{
+		{Filename: dir + "/tile0.tif", Band: 1, LUT: [][2]float64{{0, 0}, {1, 10}}},
+		{Kind: VRTAveragedSource, Filename: dir + "/tile0.tif", Band: 1, ScaleRatio: 2},
+		{Kind: VRTComplexSource, Filename: dir + "/tile0.tif"},
+	} {
+		invalidVRT := NewVRTDataset(4, 2)
+		invalidVRT.AddBand(Byte).AddSource(invalid)
+		if _, err := invalidVRT.XML(); err == nil {
+			t.Errorf("expected error for source %+v", invalid)
+		}
+	}
+	untyped := NewVRTDataset(4, 2)
+	untyped.AddBand(Unknown)
+	if _, err := untyped.XML(); err == nil {
+		t.Error("expected error for band without data type")
+	}
+}
@@ -12,13 +12,44 @@ static int goGDALProgressFuncProxyB_(
 	const char *message, 
 	void *progressArg
 ) {
-	GoInterface* args = (GoInterface*)progressArg;
-	int returnVal = goGDALProgressFuncProxyA(complete, (char*)message, args);
-	return (int)returnVal;
+	uintptr_t handle = (uintptr_t)progressArg;
+	return goGDALProgressFuncProxyA(complete, (char*)message, handle);
 }
 
 GDALProgressFunc goGDALProgressFuncProxyB() {
 	return goGDALProgressFuncProxyB_;
 }
 
+void *goGDALProgressArg(uintptr_t handle) {
+	return (void*)handle;
+}
+
+static void CPL_STDCALL goCPLErrorHandlerProxyB_(
+	CPLErr errClass,
+	int errNum,
+	const char *message
+) {
+	uintptr_t handle = (uintptr_t)CPLGetErrorHandlerUserData();
+	goCPLErrorHandlerProxyA((int)errClass, errNum, (char*)message, handle);
+}
+
+void goCPLPushErrorHandler(uintptr_t handle) {
+	CPLPushErrorHandlerEx(goCPLErrorHandlerProxyB_, (void*)handle);
+}
+
+static void CPL_STDCALL goCPLLogHandlerProxyB_(
+	CPLErr errClass,
+	int errNum,
+	const char *message
+) {
+	goCPLLogHandlerProxyA((int)errClass, errNum, (char*)message);
+}
+
+void goCPLSetLogHandler(int enable) {
+	if (enable) {
+		CPLSetErrorHandler(goCPLLogHandlerProxyB_);
+	} else {
+		CPLSetErrorHandler(CPLDefaultErrorHandler);
+	}
+}
 
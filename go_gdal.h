@@ -5,10 +5,14 @@
 #ifndef GO_GDAL_H_
 #define GO_GDAL_H_
 
+#include <stdint.h>
+
 #include <gdal.h>
 #include <gdal_alg.h>
+#include <gdal_utils.h>
 #include <gdalwarper.h>
 #include <cpl_conv.h>
+#include <cpl_error.h>
 #include <ogr_srs_api.h>
 #include <cpl_vsi.h>
 #include <cpl_string.h>
@@ -16,6 +20,15 @@
 // transform GDALProgressFunc to go func
 GDALProgressFunc goGDALProgressFuncProxyB();
 
+// progress argument identifying a go progress func by its cgo.Handle
+void *goGDALProgressArg(uintptr_t handle);
+
+// push a CPLErrorHandler forwarding to the go error capture behind handle
+void goCPLPushErrorHandler(uintptr_t handle);
+
+// install or remove the go log handler as the global CPLErrorHandler
+void goCPLSetLogHandler(int enable);
+
 #endif // GO_GDAL_H_
 
 
@@ -0,0 +1,196 @@
+package gdal
+
+import (
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Ownership of C handles.                                         */
+/* -------------------------------------------------------------------- */
+
+// owner tracks a C object created by this package on behalf of the
+// caller.  It is shared by every copy of the Go value wrapping the handle,
+// so the object is released at most once however many copies are closed.
+type owner struct {
+	mu      sync.Mutex
+	done    bool
+	closed  bool
+	kind    string
+	release func() error
+	stack   []uintptr
+	closing []uintptr
+	// Warnings emitted while creating the object, set before it is shared
+	warnings []ErrorMessage
+}
+
+var finalizers atomic.Bool
+
+// Enable or disable finalizer mode.  In finalizer mode, objects created
+// afterwards that become unreachable without being closed are closed by a
+// finalizer, and a warning naming the allocation site is logged through
+// the logger installed by SetLogHandler, or slog.Default() if none is set.
+//
+// Finalizers only track the owning value: keep a Dataset or DataSource
+// reachable while using bands, layers or features obtained from it.
+func SetFinalizers(enabled bool) {
+	finalizers.Store(enabled)
+}
+
+// Start tracking an object of the given kind released by release.  release
+// must not reference the Go value wrapping the object, or the finalizer
+// will never run.
+func newOwner(kind string, release func() error) *owner {
+	o := &owner{kind: kind, release: release}
+	if debugHandles || finalizers.Load() {
+		o.stack = callers(4)
+	}
+	if finalizers.Load() {
+		runtime.SetFinalizer(o, (*owner).finalize)
+	}
+	return o
+}
+
+func callers(skip int) []uintptr {
+	pc := make([]uintptr, 32)
+	return pc[:runtime.Callers(skip, pc)]
+}
+
+// Release the object through its owner, or directly through release if
+// the value does not own the object.  Releasing an owned object more than
+// once is a no-op.
+func (o *owner) close(release func() error) error {
+	if o == nil {
+		return release()
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.done {
+		return nil
+	}
+	o.done = true
+	o.closed = true
+	if debugHandles {
+		o.closing = callers(4)
+	}
+	runtime.SetFinalizer(o, nil)
+	return o.release()
+}
+
+// Record that ownership of the object passed to GDAL, which will release
+// it, so that closing the Go value becomes a no-op
+func (o *owner) disown() {
+	if o == nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.done = true
+	runtime.SetFinalizer(o, nil)
+}
+
+// Panic if the object, or the object it was obtained from, has been
+// closed.  Checks are only made in builds with the gdaldebug tag.
+func (o *owner) check(kind string) {
+	if !debugHandles || o == nil {
+		return
+	}
+
+	o.mu.Lock()
+	closed := o.closed
+	o.mu.Unlock()
+	if !closed {
+		return
+	}
+
+	msg := "gdal: " + kind + " used after its " + o.kind + " was closed"
+	if kind == o.kind {
+		msg = "gdal: " + kind + " used after Close"
+	}
+	panic(msg + "\n\nallocated at:\n" + formatStack(o.stack) +
+		"\n\nclosed at:\n" + formatStack(o.closing))
+}
+
+func (o *owner) finalize() {
+	err := o.close(nil)
+
+	l := logger.Load()
+	if l == nil {
+		l = slog.Default()
+	}
+	attrs := []any{slog.String("allocated", formatStack(o.stack))}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+	l.Warn("gdal: closed leaked "+o.kind+" in finalizer", attrs...)
+}
+
+// Format a recorded call stack
+func formatStack(stack []uintptr) string {
+	if len(stack) == 0 {
+		return "\t(unknown)"
+	}
+	var b strings.Builder
+	frames := runtime.CallersFrames(stack)
+	for {
+		frame, more := frames.Next()
+		b.WriteString(frame.Function)
+		b.WriteString("\n\t")
+		b.WriteString(frame.File)
+		b.WriteString(":")
+		b.WriteString(strconv.Itoa(frame.Line))
+		if !more {
+			break
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+/* -------------------------------------------------------------------- */
+/*      Use-after-close checks.                                         */
+/* -------------------------------------------------------------------- */
+
+func (dataset Dataset) check() {
+	dataset.own.check("Dataset")
+}
+
+func (rasterBand RasterBand) check() {
+	rasterBand.parent.check("RasterBand")
+}
+
+func (reader AsyncReader) check() {
+	reader.own.check("AsyncReader")
+	reader.parent.check("AsyncReader")
+}
+
+func (ds DataSource) check() {
+	ds.own.check("DataSource")
+}
+
+func (layer Layer) check() {
+	layer.parent.check("Layer")
+}
+
+func (feature Feature) check() {
+	feature.own.check("Feature")
+}
+
+func (geom Geometry) check() {
+	geom.own.check("Geometry")
+	geom.parent.check("Geometry")
+}
+
+// Return the owner of the geometry, or of the object it was obtained from
+func (geom Geometry) root() *owner {
+	if geom.own != nil {
+		return geom.own
+	}
+	return geom.parent
+}
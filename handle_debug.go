@@ -0,0 +1,6 @@
+//go:build gdaldebug
+
+package gdal
+
+// Panic when a handle is used after the object owning it was closed
+const debugHandles = true
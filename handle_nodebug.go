@@ -0,0 +1,5 @@
+//go:build !gdaldebug
+
+package gdal
+
+const debugHandles = false
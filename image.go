@@ -0,0 +1,438 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  CFLAGS: -I/usr/include/gdal
+#cgo linux  LDFLAGS: -lgdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sync"
+)
+
+/* -------------------------------------------------------------------- */
+/*      image.Image adapter.                                            */
+/* -------------------------------------------------------------------- */
+
+// Number of blocks kept in memory by an Image
+const imageCacheBlocks = 64
+
+// Image is a read-only image.Image view of up to four raster bands.
+// Pixels are read a block at a time as they are accessed and the most
+// recently read blocks are cached.
+//
+// One band is read as gray, or through its color table if it is a Byte
+// palette band, in which case the Image also implements
+// image.PalettedImage.  Two bands are read as gray and alpha, three as red,
+// green and blue, and four as red, green, blue and alpha.  Byte bands give
+// 8 bit colors and UInt16 bands 16 bit colors; all bands must have the same
+// data type.  Bands of other data types are rejected, as their values do
+// not map onto colors without scaling; convert them first, for example
+// with Translate.
+//
+// Read errors cannot be returned from At; they yield transparent pixels and
+// are reported by Err.
+type Image struct {
+	bands   []RasterBand
+	rect    image.Rectangle
+	deep    bool
+	alpha   bool
+	palette color.Palette
+	blockX  int
+	blockY  int
+
+	mu     sync.Mutex
+	blocks map[image.Point][]uint16
+	order  []image.Point
+	err    error
+}
+
+// Return an image.Image view of the given bands, or of all bands if none
+// are given
+func (dataset Dataset) Image(bands ...int) (*Image, error) {
+	dataset.check()
+	if len(bands) == 0 {
+		for i := 1; i <= dataset.RasterCount(); i++ {
+			bands = append(bands, i)
+		}
+	}
+	rasterBands := make([]RasterBand, len(bands))
+	for i, band := range bands {
+		if band < 1 || band > dataset.RasterCount() {
+			return nil, fmt.Errorf("Error: band %d is out of range (dataset has %d bands)", band, dataset.RasterCount())
+		}
+		rasterBands[i] = dataset.RasterBand(band)
+	}
+	return newImage(rasterBands)
+}
+
+// Return an image.Image view of the band
+func (rasterBand RasterBand) Image() (*Image, error) {
+	rasterBand.check()
+	return newImage([]RasterBand{rasterBand})
+}
+
+func newImage(bands []RasterBand) (*Image, error) {
+	if len(bands) == 0 || len(bands) > 4 {
+		return nil, fmt.Errorf("Error: cannot build an image from %d bands (must be 1 to 4)", len(bands))
+	}
+	first := bands[0]
+	img := &Image{
+		bands:  bands,
+		rect:   image.Rect(0, 0, first.XSize(), first.YSize()),
+		alpha:  len(bands) == 2 || len(bands) == 4,
+		blocks: make(map[image.Point][]uint16),
+	}
+	img.blockX, img.blockY = first.BlockSize()
+	if img.blockX <= 0 || img.blockY <= 0 {
+		img.blockX, img.blockY = img.rect.Dx(), 1
+	}
+	for _, band := range bands {
+		if band.XSize() != img.rect.Dx() || band.YSize() != img.rect.Dy() {
+			return nil, fmt.Errorf("Error: bands of an image must all have the same size")
+		}
+		switch dataType := band.RasterDataType(); dataType {
+		case Byte, UInt16:
+			if dataType != first.RasterDataType() {
+				return nil, fmt.Errorf("Error: bands of an image must all have the same data type")
+			}
+			img.deep = dataType == UInt16
+		default:
+			return nil, fmt.Errorf("Error: cannot build an image from %s bands (must be Byte or UInt16)", dataType.Name())
+		}
+	}
+	if len(bands) == 1 && !img.deep && first.ColorInterp() == CI_PaletteIndex {
+		if ct := first.ColorTable(); ct.cval != nil {
+			img.palette = ct.Palette()
+		}
+	}
+	return img, nil
+}
+
+// Return the color table as a Go palette
+func (ct ColorTable) Palette() color.Palette {
+	count := ct.EntryCount()
+	palette := make(color.Palette, count)
+	for i := range palette {
+		var entry C.GDALColorEntry
+		C.GDALGetColorEntryAsRGB(ct.cval, C.int(i), &entry)
+		palette[i] = color.NRGBA{uint8(entry.c1), uint8(entry.c2), uint8(entry.c3), uint8(entry.c4)}
+	}
+	return palette
+}
+
+// Construct a color table holding the colors of a Go palette
+func CreateColorTableFromPalette(palette color.Palette) ColorTable {
+	ct := CreateColorTable(PI_RGB)
+	for i, c := range palette {
+		nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+		entry := C.GDALColorEntry{
+			c1: C.short(nrgba.R), c2: C.short(nrgba.G), c3: C.short(nrgba.B), c4: C.short(nrgba.A),
+		}
+		C.GDALSetColorEntry(ct.cval, C.int(i), &entry)
+	}
+	return ct
+}
+
+// Implement image.Image
+func (img *Image) ColorModel() color.Model {
+	switch {
+	case img.palette != nil:
+		return img.palette
+	case len(img.bands) == 1 && img.deep:
+		return color.Gray16Model
+	case len(img.bands) == 1:
+		return color.GrayModel
+	case img.alpha && img.deep:
+		return color.NRGBA64Model
+	case img.alpha:
+		return color.NRGBAModel
+	case img.deep:
+		return color.RGBA64Model
+	}
+	return color.RGBAModel
+}
+
+// Implement image.Image
+func (img *Image) Bounds() image.Rectangle {
+	return img.rect
+}
+
+// Implement image.Image
+func (img *Image) At(x, y int) color.Color {
+	if img.palette != nil {
+		i := int(img.ColorIndexAt(x, y))
+		if i < len(img.palette) {
+			return img.palette[i]
+		}
+		return color.NRGBA{}
+	}
+
+	s, ok := img.samples(x, y)
+	if !ok {
+		return color.NRGBA{}
+	}
+	if img.deep {
+		switch len(s) {
+		case 1:
+			return color.Gray16{s[0]}
+		case 2:
+			return color.NRGBA64{s[0], s[0], s[0], s[1]}
+		case 3:
+			return color.RGBA64{s[0], s[1], s[2], 0xffff}
+		}
+		return color.NRGBA64{s[0], s[1], s[2], s[3]}
+	}
+	switch len(s) {
+	case 1:
+		return color.Gray{uint8(s[0])}
+	case 2:
+		return color.NRGBA{uint8(s[0]), uint8(s[0]), uint8(s[0]), uint8(s[1])}
+	case 3:
+		return color.RGBA{uint8(s[0]), uint8(s[1]), uint8(s[2]), 0xff}
+	}
+	return color.NRGBA{uint8(s[0]), uint8(s[1]), uint8(s[2]), uint8(s[3])}
+}
+
+// Implement image.PalettedImage for palette bands
+func (img *Image) ColorIndexAt(x, y int) uint8 {
+	s, ok := img.samples(x, y)
+	if !ok {
+		return 0
+	}
+	return uint8(s[0])
+}
+
+// Return the first error raised while reading pixels
+func (img *Image) Err() error {
+	img.mu.Lock()
+	defer img.mu.Unlock()
+	return img.err
+}
+
+// Fetch the samples of every band at x, y
+func (img *Image) samples(x, y int) ([]uint16, bool) {
+	if !(image.Point{x, y}.In(img.rect)) {
+		return nil, false
+	}
+
+	img.mu.Lock()
+	defer img.mu.Unlock()
+	key := image.Point{x / img.blockX, y / img.blockY}
+	block, ok := img.blocks[key]
+	if !ok {
+		var err error
+		block, err = img.readBlock(key)
+		if err != nil {
+			if img.err == nil {
+				img.err = err
+			}
+			return nil, false
+		}
+		if len(img.order) == imageCacheBlocks {
+			delete(img.blocks, img.order[0])
+			img.order = img.order[1:]
+		}
+		img.blocks[key] = block
+		img.order = append(img.order, key)
+	}
+
+	xOff, yOff := key.X*img.blockX, key.Y*img.blockY
+	width := min(img.blockX, img.rect.Dx()-xOff)
+	n := len(img.bands)
+	i := ((y-yOff)*width + x - xOff) * n
+	return block[i : i+n], true
+}
+
+// Read a block of every band into a pixel interleaved buffer
+func (img *Image) readBlock(key image.Point) ([]uint16, error) {
+	xOff, yOff := key.X*img.blockX, key.Y*img.blockY
+	width := min(img.blockX, img.rect.Dx()-xOff)
+	height := min(img.blockY, img.rect.Dy()-yOff)
+	n := len(img.bands)
+	block := make([]uint16, width*height*n)
+	for i, band := range img.bands {
+		err := RasterIO(band, Read, xOff, yOff, width, height, block[i:], width, height, 2*n, 2*n*width)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return block, nil
+}
+
+/* -------------------------------------------------------------------- */
+/*      Writing Go images.                                              */
+/* -------------------------------------------------------------------- */
+
+// Create a dataset holding the pixels of img, georeferenced by
+// geoTransform and projection; a zero geoTransform or empty projection is
+// not written.
+//
+// Gray and paletted images give one band, other opaque images red, green
+// and blue bands, and images with transparency an additional alpha band.
+// Gray16, RGBA64 and NRGBA64 images are written as UInt16 and all others
+// as Byte.  Drivers that can only copy datasets, such as PNG and JPEG, are
+// given an in-memory copy of the image through CreateCopy.
+func (driver Driver) CreateFromImage(
+	filename string,
+	img image.Image,
+	geoTransform [6]float64,
+	projection string,
+	options []string,
+) (Dataset, error) {
+	switch {
+	case driver.MetadataItem(DCAP_CREATE, "") == "YES":
+		dataset, err := createFromImage(driver, filename, img, options)
+		if err != nil {
+			return Dataset{}, err
+		}
+		err = georeference(dataset, geoTransform, projection)
+		if err != nil {
+			dataset.Close()
+			return Dataset{}, err
+		}
+		return dataset, nil
+
+	case driver.MetadataItem(DCAP_CREATECOPY, "") == "YES":
+		mem, err := GetDriverByName("MEM")
+		if err != nil {
+			return Dataset{}, err
+		}
+		source, err := createFromImage(mem, "", img, nil)
+		if err != nil {
+			return Dataset{}, err
+		}
+		defer source.Close()
+		err = georeference(source, geoTransform, projection)
+		if err != nil {
+			return Dataset{}, err
+		}
+		return driver.CreateCopy(filename, source, 0, options, nil, nil)
+	}
+	return Dataset{}, fmt.Errorf("Error: driver %s cannot create datasets", driver.ShortName())
+}
+
+func georeference(dataset Dataset, geoTransform [6]float64, projection string) error {
+	if geoTransform != [6]float64{} {
+		if err := dataset.SetGeoTransform(geoTransform); err != nil {
+			return err
+		}
+	}
+	if projection != "" {
+		return dataset.SetProjection(projection)
+	}
+	return nil
+}
+
+// Create a dataset laid out for img and write its pixels
+func createFromImage(driver Driver, filename string, img image.Image, options []string) (Dataset, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	var interps []ColorInterp
+	var palette color.Palette
+	dataType := Byte
+	switch m := img.ColorModel().(type) {
+	case color.Palette:
+		interps, palette = []ColorInterp{CI_PaletteIndex}, m
+	default:
+		switch m {
+		case color.GrayModel:
+			interps = []ColorInterp{CI_GrayIndex}
+		case color.Gray16Model:
+			interps, dataType = []ColorInterp{CI_GrayIndex}, UInt16
+		case color.RGBA64Model, color.NRGBA64Model:
+			interps, dataType = []ColorInterp{CI_RedBand, CI_GreenBand, CI_BlueBand, CI_AlphaBand}, UInt16
+		default:
+			interps = []ColorInterp{CI_RedBand, CI_GreenBand, CI_BlueBand, CI_AlphaBand}
+		}
+	}
+	if len(interps) == 4 {
+		if opaque, ok := img.(interface{ Opaque() bool }); ok && opaque.Opaque() {
+			interps = interps[:3]
+		}
+	}
+
+	dataset, err := driver.Create(filename, width, height, len(interps), dataType, options)
+	if err != nil {
+		return Dataset{}, err
+	}
+	for i, interp := range interps {
+		if err := dataset.RasterBand(i + 1).SetColorInterp(interp); err != nil {
+			dataset.Close()
+			return Dataset{}, err
+		}
+	}
+	if palette != nil {
+		ct := CreateColorTableFromPalette(palette)
+		err := dataset.RasterBand(1).SetColorTable(ct)
+		ct.Close()
+		if err != nil {
+			dataset.Close()
+			return Dataset{}, err
+		}
+	}
+
+	n := len(interps)
+	paletted, _ := img.(image.PalettedImage)
+	var buffer interface{}
+	if dataType == UInt16 {
+		pixels := make([]uint16, width*height*n)
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				p := pixels[(y*width+x)*n:]
+				c := img.At(bounds.Min.X+x, bounds.Min.Y+y)
+				if n == 1 {
+					p[0] = color.Gray16Model.Convert(c).(color.Gray16).Y
+					continue
+				}
+				nrgba := color.NRGBA64Model.Convert(c).(color.NRGBA64)
+				p[0], p[1], p[2] = nrgba.R, nrgba.G, nrgba.B
+				if n == 4 {
+					p[3] = nrgba.A
+				}
+			}
+		}
+		buffer = pixels
+	} else {
+		pixels := make([]uint8, width*height*n)
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				p := pixels[(y*width+x)*n:]
+				switch {
+				case palette != nil && paletted != nil:
+					p[0] = paletted.ColorIndexAt(bounds.Min.X+x, bounds.Min.Y+y)
+				case palette != nil:
+					p[0] = uint8(palette.Index(img.At(bounds.Min.X+x, bounds.Min.Y+y)))
+				case n == 1:
+					c := img.At(bounds.Min.X+x, bounds.Min.Y+y)
+					p[0] = color.GrayModel.Convert(c).(color.Gray).Y
+				default:
+					c := img.At(bounds.Min.X+x, bounds.Min.Y+y)
+					nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+					p[0], p[1], p[2] = nrgba.R, nrgba.G, nrgba.B
+					if n == 4 {
+						p[3] = nrgba.A
+					}
+				}
+			}
+		}
+		buffer = pixels
+	}
+
+	size := dataType.Size() / 8
+	err = dataset.IO(Write, 0, 0, width, height, buffer, width, height, n, nil, n*size, n*size*width, size)
+	if err != nil {
+		dataset.Close()
+		return Dataset{}, err
+	}
+	return dataset, nil
+}
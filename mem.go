@@ -0,0 +1,134 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  CFLAGS: -I/usr/include/gdal
+#cgo linux  LDFLAGS: -lgdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      In-memory datasets over caller memory.                          */
+/* -------------------------------------------------------------------- */
+
+// Create a MEM dataset whose bands read and write the given slices
+// directly, without copying.  Each slice holds the width by height pixels
+// of one band in row major order.  The slices are pinned until the dataset
+// is closed, which must happen before they are reused.
+//
+// A zero geoTransform or empty wkt is not set on the dataset.
+func NewMemDataset[T Pixel](width, height int, bands [][]T, geoTransform [6]float64, wkt string) (Dataset, error) {
+	if width <= 0 || height <= 0 {
+		return Dataset{}, fmt.Errorf("Error: invalid dataset size %dx%d", width, height)
+	}
+	pointers := make([]unsafe.Pointer, len(bands))
+	for i, band := range bands {
+		if len(band) < width*height {
+			return Dataset{}, fmt.Errorf(
+				"Error: band %d holds %d pixels, need %d for %dx%d",
+				i+1, len(band), width*height, width, height,
+			)
+		}
+		pointers[i] = unsafe.Pointer(&band[0])
+	}
+
+	pinner := new(runtime.Pinner)
+	for _, pointer := range pointers {
+		pinner.Pin(pointer)
+	}
+	return newMemDataset[T](width, height, pointers, geoTransform, wkt, pinner.Unpin)
+}
+
+// Create a MEM dataset of the given number of bands over memory allocated
+// by GDAL, returning it together with Go slices viewing each band.  The
+// memory is freed when the dataset is closed, after which the slices must
+// no longer be used.
+//
+// A zero geoTransform or empty wkt is not set on the dataset.
+func AllocMemDataset[T Pixel](width, height, bandCount int, geoTransform [6]float64, wkt string) (Dataset, [][]T, error) {
+	if width <= 0 || height <= 0 || bandCount < 0 {
+		return Dataset{}, nil, fmt.Errorf("Error: invalid dataset size %dx%dx%d", width, height, bandCount)
+	}
+	var pixel T
+	size := C.size_t(width) * C.size_t(height) * C.size_t(unsafe.Sizeof(pixel))
+	pointers := make([]unsafe.Pointer, bandCount)
+	bands := make([][]T, bandCount)
+	free := func() {
+		for _, pointer := range pointers {
+			C.VSIFree(pointer)
+		}
+	}
+	for i := range pointers {
+		pointers[i] = C.VSICalloc(1, size)
+		if pointers[i] == nil {
+			free()
+			return Dataset{}, nil, fmt.Errorf("Error: failed to allocate %d bytes", size)
+		}
+		bands[i] = unsafe.Slice((*T)(pointers[i]), width*height)
+	}
+
+	dataset, err := newMemDataset[T](width, height, pointers, geoTransform, wkt, free)
+	if err != nil {
+		return Dataset{}, nil, err
+	}
+	return dataset, bands, nil
+}
+
+// Create a MEM dataset with one band per pointer, calling free once the
+// dataset is closed, or immediately if it cannot be created
+func newMemDataset[T Pixel](
+	width, height int,
+	pointers []unsafe.Pointer,
+	geoTransform [6]float64,
+	wkt string,
+	free func(),
+) (Dataset, error) {
+	driver, err := GetDriverByName("MEM")
+	if err != nil {
+		free()
+		return Dataset{}, err
+	}
+	dataType := DataTypeOf[T]()
+	created, err := driver.Create("", width, height, 0, dataType, nil)
+	if err != nil {
+		free()
+		return Dataset{}, err
+	}
+
+	// Free the memory only after GDAL let go of it
+	created.own.disown()
+	h := created.cval
+	dataset := Dataset{h, newOwner("Dataset", func() error {
+		err := closeDataset(h)
+		free()
+		return err
+	})}
+
+	pixelSize := dataType.Size() / 8
+	for _, pointer := range pointers {
+		options := []string{
+			fmt.Sprintf("DATAPOINTER=%p", pointer),
+			fmt.Sprintf("PIXELOFFSET=%d", pixelSize),
+			fmt.Sprintf("LINEOFFSET=%d", pixelSize*width),
+		}
+		if err := dataset.AddBand(dataType, options); err != nil {
+			dataset.Close()
+			return Dataset{}, err
+		}
+	}
+	if err := georeference(dataset, geoTransform, wkt); err != nil {
+		dataset.Close()
+		return Dataset{}, err
+	}
+	return dataset, nil
+}
@@ -28,7 +28,7 @@ static inline OGRErr OGR_L_DeleteFeature_fixup( OGRLayerH l, GIntBig idx ) {
 */
 import "C"
 import (
-	"errors"
+	"fmt"
 	"reflect"
 	"time"
 	"unsafe"
@@ -65,6 +65,56 @@ const (
 	GT_GeometryCollection25D = GeometryType(C.wkbGeometryCollection25D)
 )
 
+// Fetch the name of the geometry type, such as "Point" or "3D Polygon"
+func (geomType GeometryType) Name() string {
+	name := C.OGRGeometryTypeToName(C.OGRwkbGeometryType(geomType))
+	return C.GoString(name)
+}
+
+// Error codes returned by OGR functions
+type OGRError int
+
+const (
+	OGRERR_NONE                      = OGRError(C.OGRERR_NONE)
+	OGRERR_NOT_ENOUGH_DATA           = OGRError(C.OGRERR_NOT_ENOUGH_DATA)
+	OGRERR_NOT_ENOUGH_MEMORY         = OGRError(C.OGRERR_NOT_ENOUGH_MEMORY)
+	OGRERR_UNSUPPORTED_GEOMETRY_TYPE = OGRError(C.OGRERR_UNSUPPORTED_GEOMETRY_TYPE)
+	OGRERR_UNSUPPORTED_OPERATION     = OGRError(C.OGRERR_UNSUPPORTED_OPERATION)
+	OGRERR_CORRUPT_DATA              = OGRError(C.OGRERR_CORRUPT_DATA)
+	OGRERR_FAILURE                   = OGRError(C.OGRERR_FAILURE)
+	OGRERR_UNSUPPORTED_SRS           = OGRError(C.OGRERR_UNSUPPORTED_SRS)
+	OGRERR_INVALID_HANDLE            = OGRError(C.OGRERR_INVALID_HANDLE)
+	OGRERR_NON_EXISTING_FEATURE      = OGRError(C.OGRERR_NON_EXISTING_FEATURE)
+)
+
+// OGR error codes can be compared against errors returned by this package
+// using errors.Is
+func (code OGRError) Error() string {
+	switch code {
+	case OGRERR_NONE:
+		return "OGR Error: None"
+	case OGRERR_NOT_ENOUGH_DATA:
+		return "OGR Error: Not enough data"
+	case OGRERR_NOT_ENOUGH_MEMORY:
+		return "OGR Error: Not enough memory"
+	case OGRERR_UNSUPPORTED_GEOMETRY_TYPE:
+		return "OGR Error: Unsupported geometry type"
+	case OGRERR_UNSUPPORTED_OPERATION:
+		return "OGR Error: Unsupported operation"
+	case OGRERR_CORRUPT_DATA:
+		return "OGR Error: Corrupt data"
+	case OGRERR_FAILURE:
+		return "OGR Error: General failure"
+	case OGRERR_UNSUPPORTED_SRS:
+		return "OGR Error: Unsupported SRS"
+	case OGRERR_INVALID_HANDLE:
+		return "OGR Error: Invalid handle"
+	case OGRERR_NON_EXISTING_FEATURE:
+		return "OGR Error: Non existing feature"
+	}
+	return fmt.Sprintf("OGR Error: Unknown error %d", int(code))
+}
+
 // TODO: use binary.{Big|Little}Endian instead or as well as these consts?
 type ByteOrder int
 
@@ -208,46 +258,75 @@ func BoolToCInt(in bool) (out C.int) {
 /* -------------------------------------------------------------------- */
 
 type Geometry struct {
-	cval C.OGRGeometryH
+	cval   C.OGRGeometryH
+	own    *owner
+	parent *owner
 }
 
-//Create a geometry object from its well known binary representation
+// Create a geometry object from its well known binary representation
 func CreateFromWKB(wkb []uint8, srs SpatialReference, bytes int) (Geometry, error) {
 	cString := (*C.uchar)(unsafe.Pointer(&wkb[0]))
-	var newGeom Geometry
-	return newGeom, C.OGR_G_CreateFromWkb(
-		cString, srs.cval, &newGeom.cval, C.int(bytes),
-	).Err()
+	var newGeom C.OGRGeometryH
+	err := ogrCall(func() C.OGRErr {
+		return C.OGR_G_CreateFromWkb(
+			cString, srs.cval, &newGeom, C.int(bytes),
+		)
+	})
+	return ownGeometry(newGeom), err
 }
 
-//Create a geometry object from its well known text representation
+// Create a geometry object from its well known text representation
 func CreateFromWKT(wkt string, srs SpatialReference) (Geometry, error) {
 	cString := C.CString(wkt)
 	defer C.free(unsafe.Pointer(cString))
-	var newGeom Geometry
-	return newGeom, C.OGR_G_CreateFromWkt(
-		&cString, srs.cval, &newGeom.cval,
-	).Err()
+	var newGeom C.OGRGeometryH
+	err := ogrCall(func() C.OGRErr {
+		return C.OGR_G_CreateFromWkt(
+			&cString, srs.cval, &newGeom,
+		)
+	})
+	return ownGeometry(newGeom), err
 }
 
-//Create a geometry object from its GeoJSON representation
+// Create a geometry object from its GeoJSON representation
 func CreateFromJson(_json string) Geometry {
 	cString := C.CString(_json)
 	defer C.free(unsafe.Pointer(cString))
-	var newGeom Geometry
-	newGeom.cval = C.OGR_G_CreateGeometryFromJson(cString)
-	return newGeom
+	newGeom := C.OGR_G_CreateGeometryFromJson(cString)
+	return ownGeometry(newGeom)
+}
+
+// Take ownership of a geometry handle returned by OGR
+func ownGeometry(h C.OGRGeometryH) Geometry {
+	if h == nil {
+		return Geometry{}
+	}
+	return Geometry{cval: h, own: newOwner("Geometry", func() error { return destroyGeometry(h) })}
+}
+
+func destroyGeometry(h C.OGRGeometryH) error {
+	if h != nil {
+		C.OGR_G_DestroyGeometry(h)
+	}
+	return nil
 }
 
 // Destroy geometry object
 func (geometry Geometry) Destroy() {
-	C.OGR_G_DestroyGeometry(geometry.cval)
+	geometry.Close()
+}
+
+// Destroy geometry object.  Closing a geometry created by this package more
+// than once, or after passing it to SetGeometryDirectly or
+// AddGeometryDirectly, is a no-op.
+func (geometry Geometry) Close() error {
+	return geometry.own.close(func() error { return destroyGeometry(geometry.cval) })
 }
 
 // Create an empty geometry of the desired type
 func Create(geomType GeometryType) Geometry {
 	geom := C.OGR_G_CreateGeometry(C.OGRwkbGeometryType(geomType))
-	return Geometry{geom}
+	return ownGeometry(geom)
 }
 
 // Stroke arc to linestring
@@ -270,58 +349,71 @@ func ApproximateArcAngles(
 		C.double(startAngle),
 		C.double(endAngle),
 		C.double(stepSizeDegrees))
-	return Geometry{geom}
+	return ownGeometry(geom)
 }
 
-// Convert to polygon
+// Convert to polygon, consuming the geometry
 func (geom Geometry) ForceToPolygon() Geometry {
+	geom.check()
+	geom.own.disown()
 	newGeom := C.OGR_G_ForceToPolygon(geom.cval)
-	return Geometry{newGeom}
+	return ownGeometry(newGeom)
 }
 
-// Convert to multipolygon
+// Convert to multipolygon, consuming the geometry
 func (geom Geometry) ForceToMultiPolygon() Geometry {
+	geom.check()
+	geom.own.disown()
 	newGeom := C.OGR_G_ForceToMultiPolygon(geom.cval)
-	return Geometry{newGeom}
+	return ownGeometry(newGeom)
 }
 
-// Convert to multipoint
+// Convert to multipoint, consuming the geometry
 func (geom Geometry) ForceToMultiPoint() Geometry {
+	geom.check()
+	geom.own.disown()
 	newGeom := C.OGR_G_ForceToMultiPoint(geom.cval)
-	return Geometry{newGeom}
+	return ownGeometry(newGeom)
 }
 
-// Convert to multilinestring
+// Convert to multilinestring, consuming the geometry
 func (geom Geometry) ForceToMultiLineString() Geometry {
+	geom.check()
+	geom.own.disown()
 	newGeom := C.OGR_G_ForceToMultiLineString(geom.cval)
-	return Geometry{newGeom}
+	return ownGeometry(newGeom)
 }
 
 // Get the dimension of this geometry
 func (geom Geometry) Dimension() int {
+	geom.check()
 	dim := C.OGR_G_GetDimension(geom.cval)
 	return int(dim)
 }
 
 // Get the dimension of the coordinates in this geometry
 func (geom Geometry) CoordinateDimension() int {
+	geom.check()
 	dim := C.OGR_G_GetCoordinateDimension(geom.cval)
 	return int(dim)
 }
 
 // Set the dimension of the coordinates in this geometry
 func (geom Geometry) SetCoordinateDimension(dim int) {
+	geom.check()
 	C.OGR_G_SetCoordinateDimension(geom.cval, C.int(dim))
 }
 
 // Create a copy of this geometry
 func (geom Geometry) Clone() Geometry {
+	geom.check()
 	newGeom := C.OGR_G_Clone(geom.cval)
-	return Geometry{newGeom}
+	return ownGeometry(newGeom)
 }
 
 // Compute and return the bounding envelope for this geometry
 func (geom Geometry) Envelope() Envelope {
+	geom.check()
 	var env Envelope
 	C.OGR_G_GetEnvelope(geom.cval, &env.cval)
 	return env
@@ -331,47 +423,62 @@ func (geom Geometry) Envelope() Envelope {
 
 // Assign a geometry from well known binary data
 func (geom Geometry) FromWKB(wkb []uint8, bytes int) error {
+	geom.check()
 	cString := (*C.uchar)(unsafe.Pointer(&wkb[0]))
-	return C.OGR_G_ImportFromWkb(geom.cval, cString, C.int(bytes)).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_G_ImportFromWkb(geom.cval, cString, C.int(bytes))
+	})
 }
 
 // Convert a geometry to well known binary data
 func (geom Geometry) ToWKB() ([]uint8, error) {
+	geom.check()
 	b := make([]uint8, geom.WKBSize())
 	cString := (*C.uchar)(unsafe.Pointer(&b[0]))
-	err := C.OGR_G_ExportToWkb(geom.cval, C.OGRwkbByteOrder(C.wkbNDR), cString).Err()
+	err := ogrCall(func() C.OGRErr {
+		return C.OGR_G_ExportToWkb(geom.cval, C.OGRwkbByteOrder(C.wkbNDR), cString)
+	})
 	return b, err
 }
 
 // Returns size of related binary representation
 func (geom Geometry) WKBSize() int {
+	geom.check()
 	size := C.OGR_G_WkbSize(geom.cval)
 	return int(size)
 }
 
 // Assign geometry object from its well known text representation
 func (geom Geometry) FromWKT(wkt string) error {
+	geom.check()
 	cString := C.CString(wkt)
 	defer C.free(unsafe.Pointer(cString))
-	return C.OGR_G_ImportFromWkt(geom.cval, &cString).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_G_ImportFromWkt(geom.cval, &cString)
+	})
 }
 
 // Fetch geometry as WKT
 func (geom Geometry) ToWKT() (string, error) {
+	geom.check()
 	var p *C.char
-	err := C.OGR_G_ExportToWkt(geom.cval, &p).Err()
+	err := ogrCall(func() C.OGRErr {
+		return C.OGR_G_ExportToWkt(geom.cval, &p)
+	})
 	wkt := C.GoString(p)
 	return wkt, err
 }
 
 // Fetch geometry type
 func (geom Geometry) Type() GeometryType {
+	geom.check()
 	gt := C.OGR_G_GetGeometryType(geom.cval)
 	return GeometryType(gt)
 }
 
 // Fetch geometry name
 func (geom Geometry) Name() string {
+	geom.check()
 	name := C.OGR_G_GetGeometryName(geom.cval)
 	return C.GoString(name)
 }
@@ -380,11 +487,13 @@ func (geom Geometry) Name() string {
 
 // Convert geometry to strictly 2D
 func (geom Geometry) FlattenTo2D() {
+	geom.check()
 	C.OGR_G_FlattenTo2D(geom.cval)
 }
 
 // Force rings to be closed
 func (geom Geometry) CloseRings() {
+	geom.check()
 	C.OGR_G_CloseRings(geom.cval)
 }
 
@@ -393,17 +502,19 @@ func CreateFromGML(gml string) Geometry {
 	cString := C.CString(gml)
 	defer C.free(unsafe.Pointer(cString))
 	geom := C.OGR_G_CreateFromGML(cString)
-	return Geometry{geom}
+	return ownGeometry(geom)
 }
 
 // Convert a geometry to GML format
 func (geom Geometry) ToGML() string {
+	geom.check()
 	val := C.OGR_G_ExportToGML(geom.cval)
 	return C.GoString(val)
 }
 
 // Convert a geometry to GML format with options
 func (geom Geometry) ToGML_Ex(options []string) string {
+	geom.check()
 	length := len(options)
 	opts := make([]*C.char, length+1)
 	for i := 0; i < length; i++ {
@@ -418,18 +529,21 @@ func (geom Geometry) ToGML_Ex(options []string) string {
 
 // Convert a geometry to KML format
 func (geom Geometry) ToKML() string {
+	geom.check()
 	val := C.OGR_G_ExportToKML(geom.cval, nil)
 	return C.GoString(val)
 }
 
 // Convert a geometry to JSON format
 func (geom Geometry) ToJSON() string {
+	geom.check()
 	val := C.OGR_G_ExportToJson(geom.cval)
 	return C.GoString(val)
 }
 
 // Convert a geometry to JSON format with options
 func (geom Geometry) ToJSON_ex(options []string) string {
+	geom.check()
 	length := len(options)
 	opts := make([]*C.char, length+1)
 	for i := 0; i < length; i++ {
@@ -444,118 +558,142 @@ func (geom Geometry) ToJSON_ex(options []string) string {
 
 // Fetch the spatial reference associated with this geometry
 func (geom Geometry) SpatialReference() SpatialReference {
+	geom.check()
 	spatialRef := C.OGR_G_GetSpatialReference(geom.cval)
-	return SpatialReference{spatialRef}
+	return SpatialReference{cval: spatialRef}
 }
 
 // Assign a spatial reference to this geometry
 func (geom Geometry) SetSpatialReference(spatialRef SpatialReference) {
+	geom.check()
 	C.OGR_G_AssignSpatialReference(geom.cval, spatialRef.cval)
 }
 
 // Apply coordinate transformation to geometry
 func (geom Geometry) Transform(ct CoordinateTransform) error {
-	return C.OGR_G_Transform(geom.cval, ct.cval).Err()
+	geom.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_G_Transform(geom.cval, ct.cval)
+	})
 }
 
 // Transform geometry to new spatial reference system
 func (geom Geometry) TransformTo(sr SpatialReference) error {
-	return C.OGR_G_TransformTo(geom.cval, sr.cval).Err()
+	geom.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_G_TransformTo(geom.cval, sr.cval)
+	})
 }
 
 // Simplify the geometry
 func (geom Geometry) Simplify(tolerance float64) Geometry {
+	geom.check()
 	newGeom := C.OGR_G_Simplify(geom.cval, C.double(tolerance))
-	return Geometry{newGeom}
+	return ownGeometry(newGeom)
 }
 
 // Simplify the geometry while preserving topology
 func (geom Geometry) SimplifyPreservingTopology(tolerance float64) Geometry {
+	geom.check()
 	newGeom := C.OGR_G_SimplifyPreserveTopology(geom.cval, C.double(tolerance))
-	return Geometry{newGeom}
+	return ownGeometry(newGeom)
 }
 
 // Modify the geometry such that it has no line segment longer than the given distance
 func (geom Geometry) Segmentize(distance float64) {
+	geom.check()
 	C.OGR_G_Segmentize(geom.cval, C.double(distance))
 }
 
 // Return true if these features intersect
 func (geom Geometry) Intersects(other Geometry) bool {
+	geom.check()
 	val := C.OGR_G_Intersects(geom.cval, other.cval)
 	return val != 0
 }
 
 // Return true if these features are equal
 func (geom Geometry) Equals(other Geometry) bool {
+	geom.check()
 	val := C.OGR_G_Equals(geom.cval, other.cval)
 	return val != 0
 }
 
 // Return true if the features are disjoint
 func (geom Geometry) Disjoint(other Geometry) bool {
+	geom.check()
 	val := C.OGR_G_Disjoint(geom.cval, other.cval)
 	return val != 0
 }
 
 // Return true if this feature touches the other
 func (geom Geometry) Touches(other Geometry) bool {
+	geom.check()
 	val := C.OGR_G_Touches(geom.cval, other.cval)
 	return val != 0
 }
 
 // Return true if this feature crosses the other
 func (geom Geometry) Crosses(other Geometry) bool {
+	geom.check()
 	val := C.OGR_G_Crosses(geom.cval, other.cval)
 	return val != 0
 }
 
 // Return true if this geometry is within the other
 func (geom Geometry) Within(other Geometry) bool {
+	geom.check()
 	val := C.OGR_G_Within(geom.cval, other.cval)
 	return val != 0
 }
 
 // Return true if this geometry contains the other
 func (geom Geometry) Contains(other Geometry) bool {
+	geom.check()
 	val := C.OGR_G_Contains(geom.cval, other.cval)
 	return val != 0
 }
 
 // Return true if this geometry overlaps the other
 func (geom Geometry) Overlaps(other Geometry) bool {
+	geom.check()
 	val := C.OGR_G_Overlaps(geom.cval, other.cval)
 	return val != 0
 }
 
 // Compute boundary for the geometry
 func (geom Geometry) Boundary() Geometry {
+	geom.check()
 	newGeom := C.OGR_G_Boundary(geom.cval)
-	return Geometry{newGeom}
+	return ownGeometry(newGeom)
 }
 
 // Compute convex hull for the geometry
 func (geom Geometry) ConvexHull() Geometry {
+	geom.check()
 	newGeom := C.OGR_G_ConvexHull(geom.cval)
-	return Geometry{newGeom}
+	return ownGeometry(newGeom)
 }
 
 // Compute buffer of the geometry
 func (geom Geometry) Buffer(distance float64, segments int) Geometry {
+	geom.check()
 	newGeom := C.OGR_G_Buffer(geom.cval, C.double(distance), C.int(segments))
-	return Geometry{newGeom}
+	return ownGeometry(newGeom)
 }
 
 // Compute intersection of this geometry with the other
 func (geom Geometry) Intersection(other Geometry) Geometry {
+	geom.check()
 	newGeom := C.OGR_G_Intersection(geom.cval, other.cval)
-	return Geometry{newGeom}
+	return ownGeometry(newGeom)
 }
 
 // Compute union of this geometry with the other
 func (geom Geometry) Union(other Geometry) Geometry {
+	geom.check()
 	newGeom := C.OGR_G_Union(geom.cval, other.cval)
-	return Geometry{newGeom}
+	return ownGeometry(newGeom)
 }
 
 // Unimplemented: UnionCascaded
@@ -564,41 +702,47 @@ func (geom Geometry) Union(other Geometry) Geometry {
 // Return a point guaranteed to lie on the surface
 // func (geom Geometry) PointOnSurface() Geometry {
 //	newGeom := C.OGR_G_PointOnSurface(geom.cval)
-//	return Geometry{newGeom}
+//	return Geometry{cval: newGeom}
 // }
 
 // Compute difference between this geometry and the other
 func (geom Geometry) Difference(other Geometry) Geometry {
+	geom.check()
 	newGeom := C.OGR_G_Difference(geom.cval, other.cval)
-	return Geometry{newGeom}
+	return ownGeometry(newGeom)
 }
 
 // Compute symmetric difference between this geometry and the other
 func (geom Geometry) SymmetricDifference(other Geometry) Geometry {
+	geom.check()
 	newGeom := C.OGR_G_SymDifference(geom.cval, other.cval)
-	return Geometry{newGeom}
+	return ownGeometry(newGeom)
 }
 
 // Compute distance between thie geometry and the other
 func (geom Geometry) Distance(other Geometry) float64 {
+	geom.check()
 	dist := C.OGR_G_Distance(geom.cval, other.cval)
 	return float64(dist)
 }
 
 // Compute length of geometry
 func (geom Geometry) Length() float64 {
+	geom.check()
 	length := C.OGR_G_Length(geom.cval)
 	return float64(length)
 }
 
 // Compute area of geometry
 func (geom Geometry) Area() float64 {
+	geom.check()
 	area := C.OGR_G_Area(geom.cval)
 	return float64(area)
 }
 
 // Compute centroid of geometry
 func (geom Geometry) Centroid() Geometry {
+	geom.check()
 	var centroid Geometry
 	C.OGR_G_Centroid(geom.cval, centroid.cval)
 	return centroid
@@ -606,41 +750,48 @@ func (geom Geometry) Centroid() Geometry {
 
 // Clear the geometry to its uninitialized state
 func (geom Geometry) Empty() {
+	geom.check()
 	C.OGR_G_Empty(geom.cval)
 }
 
 // Test if the geometry is empty
 func (geom Geometry) IsEmpty() bool {
+	geom.check()
 	val := C.OGR_G_IsEmpty(geom.cval)
 	return val != 0
 }
 
 // Test if the geometry is valid
 func (geom Geometry) IsValid() bool {
+	geom.check()
 	val := C.OGR_G_IsValid(geom.cval)
 	return val != 0
 }
 
 // Test if the geometry is simple
 func (geom Geometry) IsSimple() bool {
+	geom.check()
 	val := C.OGR_G_IsSimple(geom.cval)
 	return val != 0
 }
 
 // Test if the geometry is a ring
 func (geom Geometry) IsRing() bool {
+	geom.check()
 	val := C.OGR_G_IsRing(geom.cval)
 	return val != 0
 }
 
 // Polygonize a set of sparse edges
 func (geom Geometry) Polygonize() Geometry {
+	geom.check()
 	newGeom := C.OGR_G_Polygonize(geom.cval)
-	return Geometry{newGeom}
+	return ownGeometry(newGeom)
 }
 
 // Fetch number of points in the geometry
 func (geom Geometry) PointCount() int {
+	geom.check()
 	count := C.OGR_G_GetPointCount(geom.cval)
 	return int(count)
 }
@@ -649,24 +800,28 @@ func (geom Geometry) PointCount() int {
 
 // Fetch the X coordinate of a point in the geometry
 func (geom Geometry) X(index int) float64 {
+	geom.check()
 	x := C.OGR_G_GetX(geom.cval, C.int(index))
 	return float64(x)
 }
 
 // Fetch the Y coordinate of a point in the geometry
 func (geom Geometry) Y(index int) float64 {
+	geom.check()
 	y := C.OGR_G_GetY(geom.cval, C.int(index))
 	return float64(y)
 }
 
 // Fetch the Z coordinate of a point in the geometry
 func (geom Geometry) Z(index int) float64 {
+	geom.check()
 	z := C.OGR_G_GetZ(geom.cval, C.int(index))
 	return float64(z)
 }
 
 // Fetch the coordinates of a point in the geometry
 func (geom Geometry) Point(index int) (x, y, z float64) {
+	geom.check()
 	C.OGR_G_GetPoint(
 		geom.cval,
 		C.int(index),
@@ -678,6 +833,7 @@ func (geom Geometry) Point(index int) (x, y, z float64) {
 
 // Set the coordinates of a point in the geometry
 func (geom Geometry) SetPoint(index int, x, y, z float64) {
+	geom.check()
 	C.OGR_G_SetPoint(
 		geom.cval,
 		C.int(index),
@@ -688,57 +844,77 @@ func (geom Geometry) SetPoint(index int, x, y, z float64) {
 
 // Set the coordinates of a point in the geometry, ignoring the 3rd dimension
 func (geom Geometry) SetPoint2D(index int, x, y float64) {
+	geom.check()
 	C.OGR_G_SetPoint_2D(geom.cval, C.int(index), C.double(x), C.double(y))
 }
 
 // Add a new point to the geometry (line string or polygon only)
 func (geom Geometry) AddPoint(x, y, z float64) {
+	geom.check()
 	C.OGR_G_AddPoint(geom.cval, C.double(x), C.double(y), C.double(z))
 }
 
 // Add a new point to the geometry (line string or polygon only), ignoring the 3rd dimension
 func (geom Geometry) AddPoint2D(x, y float64) {
+	geom.check()
 	C.OGR_G_AddPoint_2D(geom.cval, C.double(x), C.double(y))
 }
 
 // Fetch the number of elements in the geometry, or number of geometries in the container
 func (geom Geometry) GeometryCount() int {
+	geom.check()
 	count := C.OGR_G_GetGeometryCount(geom.cval)
 	return int(count)
 }
 
 // Fetch geometry from a geometry container
 func (geom Geometry) Geometry(index int) Geometry {
+	geom.check()
 	newGeom := C.OGR_G_GetGeometryRef(geom.cval, C.int(index))
-	return Geometry{newGeom}
+	return Geometry{cval: newGeom, parent: geom.root()}
 }
 
 // Add a geometry to a geometry container
 func (geom Geometry) AddGeometry(other Geometry) error {
-	return C.OGR_G_AddGeometry(geom.cval, other.cval).Err()
+	geom.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_G_AddGeometry(geom.cval, other.cval)
+	})
 }
 
 // Add a geometry to a geometry container and assign ownership to that container
 func (geom Geometry) AddGeometryDirectly(other Geometry) error {
-	return C.OGR_G_AddGeometryDirectly(geom.cval, other.cval).Err()
+	geom.check()
+	// The container takes ownership even on failure, deleting the geometry
+	defer other.own.disown()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_G_AddGeometryDirectly(geom.cval, other.cval)
+	})
 }
 
 // Remove a geometry from the geometry container
 func (geom Geometry) RemoveGeometry(index int, delete bool) error {
-	return C.OGR_G_RemoveGeometry(geom.cval, C.int(index), BoolToCInt(delete)).Err()
+	geom.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_G_RemoveGeometry(geom.cval, C.int(index), BoolToCInt(delete))
+	})
 }
 
 // Build a polygon / ring from a set of lines
 func (geom Geometry) BuildPolygonFromEdges(autoClose bool, tolerance float64) (Geometry, error) {
+	geom.check()
 	var cErr C.OGRErr
-	newGeom := C.OGRBuildPolygonFromEdges(
-		geom.cval,
-		0,
-		BoolToCInt(autoClose),
-		C.double(tolerance),
-		&cErr,
-	)
-	return Geometry{newGeom}, cErr.Err()
+	var newGeom C.OGRGeometryH
+	messages := captureErrors(func() {
+		newGeom = C.OGRBuildPolygonFromEdges(
+			geom.cval,
+			0,
+			BoolToCInt(autoClose),
+			C.double(tolerance),
+			&cErr,
+		)
+	})
+	return ownGeometry(newGeom), ogrError(cErr, messages)
 }
 
 /* -------------------------------------------------------------------- */
@@ -771,6 +947,7 @@ const (
 
 type FieldDefinition struct {
 	cval C.OGRFieldDefnH
+	own  *owner
 }
 
 type Field struct {
@@ -782,12 +959,32 @@ func CreateFieldDefinition(name string, fieldType FieldType) FieldDefinition {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 	fieldDef := C.OGR_Fld_Create(cName, C.OGRFieldType(fieldType))
-	return FieldDefinition{fieldDef}
+	return ownFieldDefinition(fieldDef)
+}
+
+// Take ownership of a field definition handle
+func ownFieldDefinition(h C.OGRFieldDefnH) FieldDefinition {
+	if h == nil {
+		return FieldDefinition{}
+	}
+	return FieldDefinition{h, newOwner("FieldDefinition", func() error { return destroyFieldDefinition(h) })}
+}
+
+func destroyFieldDefinition(h C.OGRFieldDefnH) error {
+	if h != nil {
+		C.OGR_Fld_Destroy(h)
+	}
+	return nil
 }
 
 // Destroy the field definition
 func (fd FieldDefinition) Destroy() {
-	C.OGR_Fld_Destroy(fd.cval)
+	fd.Close()
+}
+
+// Destroy the field definition.  Closing it more than once is a no-op.
+func (fd FieldDefinition) Close() error {
+	return fd.own.close(func() error { return destroyFieldDefinition(fd.cval) })
 }
 
 // Fetch the name of the field
@@ -878,6 +1075,17 @@ func (fd FieldDefinition) SetIgnored(ignore bool) {
 	C.OGR_Fld_SetIgnored(fd.cval, BoolToCInt(ignore))
 }
 
+// Fetch whether this field can receive null values
+func (fd FieldDefinition) IsNullable() bool {
+	nullable := C.OGR_Fld_IsNullable(fd.cval)
+	return nullable != 0
+}
+
+// Set whether this field can receive null values
+func (fd FieldDefinition) SetNullable(nullable bool) {
+	C.OGR_Fld_SetNullable(fd.cval, BoolToCInt(nullable))
+}
+
 // Fetch human readable name for the field type
 func (ft FieldType) Name() string {
 	name := C.OGR_GetFieldTypeName(C.OGRFieldType(ft))
@@ -890,6 +1098,7 @@ func (ft FieldType) Name() string {
 
 type FeatureDefinition struct {
 	cval C.OGRFeatureDefnH
+	own  *owner
 }
 
 // Create a new feature definition object
@@ -897,17 +1106,44 @@ func CreateFeatureDefinition(name string) FeatureDefinition {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 	fd := C.OGR_FD_Create(cName)
-	return FeatureDefinition{fd}
+	return ownFeatureDefinition(fd)
+}
+
+// Take ownership of a feature definition handle
+func ownFeatureDefinition(h C.OGRFeatureDefnH) FeatureDefinition {
+	if h == nil {
+		return FeatureDefinition{}
+	}
+	return FeatureDefinition{h, newOwner("FeatureDefinition", func() error { return releaseFeatureDefinition(h) })}
+}
+
+func releaseFeatureDefinition(h C.OGRFeatureDefnH) error {
+	if h != nil {
+		C.OGR_FD_Release(h)
+	}
+	return nil
 }
 
 // Destroy a feature definition object
 func (fd FeatureDefinition) Destroy() {
-	C.OGR_FD_Destroy(fd.cval)
+	fd.own.close(func() error {
+		if fd.cval != nil {
+			C.OGR_FD_Destroy(fd.cval)
+		}
+		return nil
+	})
 }
 
 // Drop a reference, and delete object if no references remain
 func (fd FeatureDefinition) Release() {
-	C.OGR_FD_Release(fd.cval)
+	fd.Close()
+}
+
+// Drop the reference held by a feature definition created by
+// CreateFeatureDefinition; the object is deleted once no features refer
+// to it.  Closing it more than once is a no-op.
+func (fd FeatureDefinition) Close() error {
+	return fd.own.close(func() error { return releaseFeatureDefinition(fd.cval) })
 }
 
 // Fetch the name of this feature definition
@@ -925,7 +1161,7 @@ func (fd FeatureDefinition) FieldCount() int {
 // Fetch the definition of the indicated field
 func (fd FeatureDefinition) FieldDefinition(index int) FieldDefinition {
 	fieldDefn := C.OGR_FD_GetFieldDefn(fd.cval, C.int(index))
-	return FieldDefinition{fieldDefn}
+	return FieldDefinition{cval: fieldDefn}
 }
 
 // Fetch the index of the named field
@@ -943,7 +1179,9 @@ func (fd FeatureDefinition) AddFieldDefinition(fieldDefn FieldDefinition) {
 
 // Delete a field definition from this feature definition
 func (fd FeatureDefinition) DeleteFieldDefinition(index int) error {
-	return C.OGR_FD_DeleteFieldDefn(fd.cval, C.int(index)).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_FD_DeleteFieldDefn(fd.cval, C.int(index))
+	})
 }
 
 // Fetch the geometry base type of this feature definition
@@ -1003,79 +1241,116 @@ func (fd FeatureDefinition) ReferenceCount() int {
 
 type Feature struct {
 	cval C.OGRFeatureH
+	own  *owner
 }
 
 // Create a feature from this feature definition
 func (fd FeatureDefinition) Create() Feature {
 	feature := C.OGR_F_Create(fd.cval)
-	return Feature{feature}
+	return ownFeature(feature)
+}
+
+// Take ownership of a feature handle
+func ownFeature(h C.OGRFeatureH) Feature {
+	if h == nil {
+		return Feature{}
+	}
+	return Feature{h, newOwner("Feature", func() error { return destroyFeature(h) })}
+}
+
+func destroyFeature(h C.OGRFeatureH) error {
+	if h != nil {
+		C.OGR_F_Destroy(h)
+	}
+	return nil
 }
 
 // Destroy this feature
 func (feature Feature) Destroy() {
-	C.OGR_F_Destroy(feature.cval)
+	feature.Close()
+}
+
+// Destroy this feature.  Closing it more than once is a no-op.
+func (feature Feature) Close() error {
+	return feature.own.close(func() error { return destroyFeature(feature.cval) })
 }
 
 // Fetch feature definition
 func (feature Feature) Definition() FeatureDefinition {
+	feature.check()
 	fd := C.OGR_F_GetDefnRef(feature.cval)
-	return FeatureDefinition{fd}
+	return FeatureDefinition{cval: fd}
 }
 
 // Set feature geometry
 func (feature Feature) SetGeometry(geom Geometry) error {
-	return C.OGR_F_SetGeometry(feature.cval, geom.cval).Err()
+	feature.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_F_SetGeometry(feature.cval, geom.cval)
+	})
 }
 
 // Set feature geometry, passing ownership to the feature
 func (feature Feature) SetGeometryDirectly(geom Geometry) error {
-	return C.OGR_F_SetGeometryDirectly(feature.cval, geom.cval).Err()
+	feature.check()
+	// The feature takes ownership even on failure, deleting the geometry
+	defer geom.own.disown()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_F_SetGeometryDirectly(feature.cval, geom.cval)
+	})
 }
 
 // Fetch geometry of this feature, returning ok == false if feature has no geometry (possible in KML)
 func (feature Feature) Geometry() (g Geometry, ok bool) {
+	feature.check()
 	geom := C.OGR_F_GetGeometryRef(feature.cval)
 	if geom == nil {
 		return Geometry{}, false
 	}
-	return Geometry{geom}, true
+	return Geometry{cval: geom, parent: feature.own}, true
 }
 
 // Fetch geometry of this feature and assume ownership, returning ok == false if feature has no geometry (possible in KML)
 func (feature Feature) StealGeometry() (g Geometry, ok bool) {
+	feature.check()
 	geom := C.OGR_F_StealGeometry(feature.cval)
 	if geom == nil {
 		return Geometry{}, false
 	}
-	return Geometry{geom}, true
+	return ownGeometry(geom), true
 }
 
 // Duplicate feature
 func (feature Feature) Clone() Feature {
+	feature.check()
 	newFeature := C.OGR_F_Clone(feature.cval)
-	return Feature{newFeature}
+	return ownFeature(newFeature)
 }
 
 // Test if two features are the same
 func (f1 Feature) Equal(f2 Feature) bool {
+	f1.check()
 	equal := C.OGR_F_Equal(f1.cval, f2.cval)
 	return equal != 0
 }
 
 // Fetch number of fields on this feature
 func (feature Feature) FieldCount() int {
+	feature.check()
 	count := C.OGR_F_GetFieldCount(feature.cval)
 	return int(count)
 }
 
 // Fetch definition for the indicated field
 func (feature Feature) FieldDefinition(index int) FieldDefinition {
+	feature.check()
 	defn := C.OGR_F_GetFieldDefnRef(feature.cval, C.int(index))
-	return FieldDefinition{defn}
+	return FieldDefinition{cval: defn}
 }
 
 // Fetch the field index for the given field name
 func (feature Feature) FieldIndex(name string) int {
+	feature.check()
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 	index := C.OGR_F_GetFieldIndex(feature.cval, cName)
@@ -1084,41 +1359,48 @@ func (feature Feature) FieldIndex(name string) int {
 
 // Return if a field has ever been assigned a value
 func (feature Feature) IsFieldSet(index int) bool {
+	feature.check()
 	set := C.OGR_F_IsFieldSet(feature.cval, C.int(index))
 	return set != 0
 }
 
 // Clear a field and mark it as unset
 func (feature Feature) UnsetField(index int) {
+	feature.check()
 	C.OGR_F_UnsetField(feature.cval, C.int(index))
 }
 
 // Fetch a reference to the internal field value
 func (feature Feature) RawField(index int) Field {
+	feature.check()
 	field := C.OGR_F_GetRawFieldRef(feature.cval, C.int(index))
 	return Field{field}
 }
 
 // Fetch field value as integer
 func (feature Feature) FieldAsInteger(index int) int {
+	feature.check()
 	val := C.OGR_F_GetFieldAsInteger(feature.cval, C.int(index))
 	return int(val)
 }
 
 // Fetch field value as float64
 func (feature Feature) FieldAsFloat64(index int) float64 {
+	feature.check()
 	val := C.OGR_F_GetFieldAsDouble(feature.cval, C.int(index))
 	return float64(val)
 }
 
 // Fetch field value as string
 func (feature Feature) FieldAsString(index int) string {
+	feature.check()
 	val := C.OGR_F_GetFieldAsString(feature.cval, C.int(index))
 	return C.GoString(val)
 }
 
 // Fetch field as list of integers
 func (feature Feature) FieldAsIntegerList(index int) []int {
+	feature.check()
 	var count int
 	cArray := C.OGR_F_GetFieldAsIntegerList(feature.cval, C.int(index), (*C.int)(unsafe.Pointer(&count)))
 	var goSlice []int
@@ -1131,6 +1413,7 @@ func (feature Feature) FieldAsIntegerList(index int) []int {
 
 // Fetch field as list of float64
 func (feature Feature) FieldAsFloat64List(index int) []float64 {
+	feature.check()
 	var count int
 	cArray := C.OGR_F_GetFieldAsDoubleList(feature.cval, C.int(index), (*C.int)(unsafe.Pointer(&count)))
 	var goSlice []float64
@@ -1143,6 +1426,7 @@ func (feature Feature) FieldAsFloat64List(index int) []float64 {
 
 // Fetch field as list of strings
 func (feature Feature) FieldAsStringList(index int) []string {
+	feature.check()
 	p := C.OGR_F_GetFieldAsStringList(feature.cval, C.int(index))
 
 	var strings []string
@@ -1161,6 +1445,7 @@ func (feature Feature) FieldAsStringList(index int) []string {
 
 // Fetch field as binary data
 func (feature Feature) FieldAsBinary(index int) []uint8 {
+	feature.check()
 	var count int
 	cArray := C.OGR_F_GetFieldAsBinary(feature.cval, C.int(index), (*C.int)(unsafe.Pointer(&count)))
 	var goSlice []uint8
@@ -1173,6 +1458,7 @@ func (feature Feature) FieldAsBinary(index int) []uint8 {
 
 // Fetch field as date and time
 func (feature Feature) FieldAsDateTime(index int) (time.Time, bool) {
+	feature.check()
 	var year, month, day, hour, minute, second, tzFlag int
 	success := C.OGR_F_GetFieldAsDateTime(
 		feature.cval,
@@ -1191,16 +1477,19 @@ func (feature Feature) FieldAsDateTime(index int) (time.Time, bool) {
 
 // Set field to integer value
 func (feature Feature) SetFieldInteger(index, value int) {
+	feature.check()
 	C.OGR_F_SetFieldInteger(feature.cval, C.int(index), C.int(value))
 }
 
 // Set field to float64 value
 func (feature Feature) SetFieldFloat64(index int, value float64) {
+	feature.check()
 	C.OGR_F_SetFieldDouble(feature.cval, C.int(index), C.double(value))
 }
 
 // Set field to string value
 func (feature Feature) SetFieldString(index int, value string) {
+	feature.check()
 	cVal := C.CString(value)
 	defer C.free(unsafe.Pointer(cVal))
 	C.OGR_F_SetFieldString(feature.cval, C.int(index), cVal)
@@ -1208,6 +1497,7 @@ func (feature Feature) SetFieldString(index int, value string) {
 
 // Set field to list of integers
 func (feature Feature) SetFieldIntegerList(index int, value []int) {
+	feature.check()
 	C.OGR_F_SetFieldIntegerList(
 		feature.cval,
 		C.int(index),
@@ -1218,6 +1508,7 @@ func (feature Feature) SetFieldIntegerList(index int, value []int) {
 
 // Set field to list of float64
 func (feature Feature) SetFieldFloat64List(index int, value []float64) {
+	feature.check()
 	C.OGR_F_SetFieldDoubleList(
 		feature.cval,
 		C.int(index),
@@ -1228,6 +1519,7 @@ func (feature Feature) SetFieldFloat64List(index int, value []float64) {
 
 // Set field to list of strings
 func (feature Feature) SetFieldStringList(index int, value []string) {
+	feature.check()
 	length := len(value)
 	cValue := make([]*C.char, length+1)
 	for i := 0; i < length; i++ {
@@ -1245,11 +1537,13 @@ func (feature Feature) SetFieldStringList(index int, value []string) {
 
 // Set field from the raw field pointer
 func (feature Feature) SetFieldRaw(index int, field Field) {
+	feature.check()
 	C.OGR_F_SetFieldRaw(feature.cval, C.int(index), field.cval)
 }
 
 // Set field as binary data
 func (feature Feature) SetFieldBinary(index int, value []uint8) {
+	feature.check()
 	C.OGR_F_SetFieldBinary(
 		feature.cval,
 		C.int(index),
@@ -1260,6 +1554,7 @@ func (feature Feature) SetFieldBinary(index int, value []uint8) {
 
 // Set field as date / time
 func (feature Feature) SetFieldDateTime(index int, dt time.Time) {
+	feature.check()
 	C.OGR_F_SetFieldDateTime(
 		feature.cval,
 		C.int(index),
@@ -1275,40 +1570,52 @@ func (feature Feature) SetFieldDateTime(index int, dt time.Time) {
 
 // Fetch feature indentifier
 func (feature Feature) FID() int {
+	feature.check()
 	fid := C.OGR_F_GetFID(feature.cval)
 	return int(fid)
 }
 
 // Set feature identifier
 func (feature Feature) SetFID(fid int) error {
-	return C.OGR_F_SetFID_fixup(feature.cval, C.GIntBig(fid)).Err()
+	feature.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_F_SetFID_fixup(feature.cval, C.GIntBig(fid))
+	})
 }
 
 // Unimplemented: DumpReadable
 
 // Set one feature from another
 func (this Feature) SetFrom(other Feature, forgiving int) error {
-	return C.OGR_F_SetFrom(this.cval, other.cval, C.int(forgiving)).Err()
+	this.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_F_SetFrom(this.cval, other.cval, C.int(forgiving))
+	})
 }
 
 // Set one feature from another, using field map
 func (this Feature) SetFromWithMap(other Feature, forgiving int, fieldMap []int) error {
-	return C.OGR_F_SetFromWithMap(
-		this.cval,
-		other.cval,
-		C.int(forgiving),
-		(*C.int)(unsafe.Pointer(&fieldMap[0])),
-	).Err()
+	this.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_F_SetFromWithMap(
+			this.cval,
+			other.cval,
+			C.int(forgiving),
+			(*C.int)(unsafe.Pointer(&fieldMap[0])),
+		)
+	})
 }
 
 // Fetch style string for this feature
 func (feature Feature) StyleString() string {
+	feature.check()
 	style := C.OGR_F_GetStyleString(feature.cval)
 	return C.GoString(style)
 }
 
 // Set style string for this feature
 func (feature Feature) SetStyleString(style string) {
+	feature.check()
 	cStyle := C.CString(style)
 	C.OGR_F_SetStyleStringDirectly(feature.cval, cStyle)
 }
@@ -1318,34 +1625,40 @@ func (feature Feature) SetStyleString(style string) {
 /* -------------------------------------------------------------------- */
 
 type Layer struct {
-	cval C.OGRLayerH
+	cval   C.OGRLayerH
+	parent *owner
 }
 
 // Return the layer name
 func (layer Layer) Name() string {
+	layer.check()
 	name := C.OGR_L_GetName(layer.cval)
 	return C.GoString(name)
 }
 
 // Return the layer geometry type
 func (layer Layer) Type() GeometryType {
+	layer.check()
 	gt := C.OGR_L_GetGeomType(layer.cval)
 	return GeometryType(gt)
 }
 
 // Return the current spatial filter for this layer
 func (layer Layer) SpatialFilter() Geometry {
+	layer.check()
 	geom := C.OGR_L_GetSpatialFilter(layer.cval)
-	return Geometry{geom}
+	return Geometry{cval: geom, parent: layer.parent}
 }
 
 // Set a new spatial filter for this layer
 func (layer Layer) SetSpatialFilter(filter Geometry) {
+	layer.check()
 	C.OGR_L_SetSpatialFilter(layer.cval, filter.cval)
 }
 
 // Set a new rectangular spatial filter for this layer
 func (layer Layer) SetSpatialFilterRect(minX, minY, maxX, maxY float64) {
+	layer.check()
 	C.OGR_L_SetSpatialFilterRect(
 		layer.cval,
 		C.double(minX), C.double(minY), C.double(maxX), C.double(maxY),
@@ -1354,78 +1667,103 @@ func (layer Layer) SetSpatialFilterRect(minX, minY, maxX, maxY float64) {
 
 // Set a new attribute query filter
 func (layer Layer) SetAttributeFilter(filter string) error {
+	layer.check()
 	cFilter := C.CString(filter)
 	defer C.free(unsafe.Pointer(cFilter))
-	return C.OGR_L_SetAttributeFilter(layer.cval, cFilter).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_L_SetAttributeFilter(layer.cval, cFilter)
+	})
 }
 
 // Reset reading to start on the first featre
 func (layer Layer) ResetReading() {
+	layer.check()
 	C.OGR_L_ResetReading(layer.cval)
 }
 
 // Fetch the next available feature from this layer; call Destroy() on it when done; returns ok =
 // false if there are no more features in the layer.
 func (layer Layer) NextFeature() (f Feature, ok bool) {
+	layer.check()
 	feature := C.OGR_L_GetNextFeature(layer.cval)
 	if feature == nil {
 		return Feature{}, false
 	}
-	return Feature{feature}, true
+	return ownFeature(feature), true
 }
 
 // Move read cursor to the provided index
 func (layer Layer) SetNextByIndex(index int) error {
-	return C.OGR_L_SetNextByIndex_fixup(layer.cval, C.GIntBig(index)).Err()
+	layer.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_L_SetNextByIndex_fixup(layer.cval, C.GIntBig(index))
+	})
 }
 
 // Fetch a feature by its index
 func (layer Layer) Feature(index int) Feature {
+	layer.check()
 	feature := C.OGR_L_GetFeature_fixup(layer.cval, C.GIntBig(index))
-	return Feature{feature}
+	return ownFeature(feature)
 }
 
 // Rewrite the provided feature
 func (layer Layer) SetFeature(feature Feature) error {
-	return C.OGR_L_SetFeature(layer.cval, feature.cval).Err()
+	layer.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_L_SetFeature(layer.cval, feature.cval)
+	})
 }
 
 // Create and write a new feature within a layer
 func (layer Layer) Create(feature Feature) error {
-	return C.OGR_L_CreateFeature(layer.cval, feature.cval).Err()
+	layer.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_L_CreateFeature(layer.cval, feature.cval)
+	})
 }
 
 // Delete indicated feature from layer
 func (layer Layer) Delete(index int) error {
-	return C.OGR_L_DeleteFeature_fixup(layer.cval, C.GIntBig(index)).Err()
+	layer.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_L_DeleteFeature_fixup(layer.cval, C.GIntBig(index))
+	})
 }
 
 // Fetch the schema information for this layer
 func (layer Layer) Definition() FeatureDefinition {
+	layer.check()
 	defn := C.OGR_L_GetLayerDefn(layer.cval)
-	return FeatureDefinition{defn}
+	return FeatureDefinition{cval: defn}
 }
 
 // Fetch the spatial reference system for this layer
 func (layer Layer) SpatialReference() SpatialReference {
+	layer.check()
 	sr := C.OGR_L_GetSpatialRef(layer.cval)
-	return SpatialReference{sr}
+	return SpatialReference{cval: sr}
 }
 
 // Fetch the feature count for this layer
 func (layer Layer) FeatureCount(force bool) (count int, ok bool) {
+	layer.check()
 	count = int(C.OGR_L_GetFeatureCount(layer.cval, BoolToCInt(force)))
 	return count, count != -1
 }
 
 // Fetch the extent of this layer
 func (layer Layer) Extent(force bool) (env Envelope, err error) {
-	err = C.OGR_L_GetExtent(layer.cval, &env.cval, BoolToCInt(force)).Err()
+	layer.check()
+	err = ogrCall(func() C.OGRErr {
+		return C.OGR_L_GetExtent(layer.cval, &env.cval, BoolToCInt(force))
+	})
 	return
 }
 
 // Test if this layer supports the named capability
 func (layer Layer) TestCapability(capability string) bool {
+	layer.check()
 	cString := C.CString(capability)
 	defer C.free(unsafe.Pointer(cString))
 	val := C.OGR_L_TestCapability(layer.cval, cString)
@@ -1434,63 +1772,93 @@ func (layer Layer) TestCapability(capability string) bool {
 
 // Create a new field on a layer
 func (layer Layer) CreateField(fd FieldDefinition, approxOK bool) error {
-	return C.OGR_L_CreateField(layer.cval, fd.cval, BoolToCInt(approxOK)).Err()
+	layer.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_L_CreateField(layer.cval, fd.cval, BoolToCInt(approxOK))
+	})
 }
 
 // Delete a field from the layer
 func (layer Layer) DeleteField(index int) error {
-	return C.OGR_L_DeleteField(layer.cval, C.int(index)).Err()
+	layer.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_L_DeleteField(layer.cval, C.int(index))
+	})
 }
 
 // Reorder all the fields of a layer
 func (layer Layer) ReorderFields(layerMap []int) error {
-	return C.OGR_L_ReorderFields(layer.cval, (*C.int)(unsafe.Pointer(&layerMap[0]))).Err()
+	layer.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_L_ReorderFields(layer.cval, (*C.int)(unsafe.Pointer(&layerMap[0])))
+	})
 }
 
 // Reorder an existing field of a layer
 func (layer Layer) ReorderField(oldIndex, newIndex int) error {
-	return C.OGR_L_ReorderField(layer.cval, C.int(oldIndex), C.int(newIndex)).Err()
+	layer.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_L_ReorderField(layer.cval, C.int(oldIndex), C.int(newIndex))
+	})
 }
 
 // Alter the definition of an existing field of a layer
 func (layer Layer) AlterFieldDefn(index int, newDefn FieldDefinition, flags int) error {
-	return C.OGR_L_AlterFieldDefn(layer.cval, C.int(index), newDefn.cval, C.int(flags)).Err()
+	layer.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_L_AlterFieldDefn(layer.cval, C.int(index), newDefn.cval, C.int(flags))
+	})
 }
 
 // Begin a transation on data sources which support it
 func (layer Layer) StartTransaction() error {
-	return C.OGR_L_StartTransaction(layer.cval).Err()
+	layer.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_L_StartTransaction(layer.cval)
+	})
 }
 
 // Commit a transaction on data sources which support it
 func (layer Layer) CommitTransaction() error {
-	return C.OGR_L_CommitTransaction(layer.cval).Err()
+	layer.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_L_CommitTransaction(layer.cval)
+	})
 }
 
 // Roll back the current transaction on data sources which support it
 func (layer Layer) RollbackTransaction() error {
-	return C.OGR_L_RollbackTransaction(layer.cval).Err()
+	layer.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_L_RollbackTransaction(layer.cval)
+	})
 }
 
 // Flush pending changes to the layer
 func (layer Layer) Sync() error {
-	return C.OGR_L_SyncToDisk(layer.cval).Err()
+	layer.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_L_SyncToDisk(layer.cval)
+	})
 }
 
 // Fetch the name of the FID column
 func (layer Layer) FIDColumn() string {
+	layer.check()
 	name := C.OGR_L_GetFIDColumn(layer.cval)
 	return C.GoString(name)
 }
 
 // Fetch the name of the geometry column
 func (layer Layer) GeometryColumn() string {
+	layer.check()
 	name := C.OGR_L_GetGeometryColumn(layer.cval)
 	return C.GoString(name)
 }
 
 // Set which fields can be ignored when retrieving features from the layer
 func (layer Layer) SetIgnoredFields(names []string) error {
+	layer.check()
 	length := len(names)
 	cNames := make([]*C.char, length+1)
 	for i := 0; i < length; i++ {
@@ -1499,7 +1867,9 @@ func (layer Layer) SetIgnoredFields(names []string) error {
 	}
 	cNames[length] = (*C.char)(unsafe.Pointer(nil))
 
-	return C.OGR_L_SetIgnoredFields(layer.cval, (**C.char)(unsafe.Pointer(&cNames[0]))).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_L_SetIgnoredFields(layer.cval, (**C.char)(unsafe.Pointer(&cNames[0])))
+	})
 }
 
 // Return the intersection of two layers
@@ -1536,17 +1906,21 @@ func (layer Layer) SetIgnoredFields(names []string) error {
 
 type DataSource struct {
 	cval C.OGRDataSourceH
+	own  *owner
 }
 
 // Open a file / data source with one of the registered drivers; call Release() on it when done
 func OpenDataSource(name string, update int) (DataSource, error) {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	ds := C.OGROpen(cName, C.int(update), nil)
+	var ds C.OGRDataSourceH
+	messages := captureErrors(func() {
+		ds = C.OGROpen(cName, C.int(update), nil)
+	})
 	if ds == nil {
-		return DataSource{}, errors.New("Failed to open " + name)
+		return DataSource{}, failure(messages, "Failed to open %s", name)
 	}
-	return DataSource{ds}, nil
+	return ownDataSource(ds), nil
 }
 
 // Open a shared file / data source with one of the registered drivers
@@ -1554,12 +1928,58 @@ func OpenSharedDataSource(name string, update int) DataSource {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 	ds := C.OGROpenShared(cName, C.int(update), nil)
-	return DataSource{ds}
+	return ownSharedDataSource(ds)
+}
+
+// Take ownership of a data source handle
+func ownDataSource(h C.OGRDataSourceH) DataSource {
+	if h == nil {
+		return DataSource{}
+	}
+	return DataSource{h, newOwner("DataSource", func() error { return destroyDataSource(h) })}
+}
+
+// Take ownership of a reference to a shared data source handle
+func ownSharedDataSource(h C.OGRDataSourceH) DataSource {
+	if h == nil {
+		return DataSource{}
+	}
+	return DataSource{h, newOwner("DataSource", func() error { return releaseDataSource(h) })}
+}
+
+// Close the data source, reporting errors raised while flushing pending
+// writes
+func destroyDataSource(h C.OGRDataSourceH) error {
+	if h == nil {
+		return nil
+	}
+	messages := captureErrors(func() { C.OGR_DS_Destroy(h) })
+	for _, message := range messages {
+		if message.Class >= CE_Failure {
+			return newError(CE_Failure, messages)
+		}
+	}
+	return nil
+}
+
+func releaseDataSource(h C.OGRDataSourceH) error {
+	if h == nil {
+		return nil
+	}
+	return ogrCall(func() C.OGRErr {
+		return C.OGRReleaseDataSource(h)
+	})
 }
 
 // Drop a reference to this datasource and destroy if reference is zero
 func (ds DataSource) Release() error {
-	return C.OGRReleaseDataSource(ds.cval).Err()
+	return ds.own.close(func() error { return releaseDataSource(ds.cval) })
+}
+
+// Close the data source, flushing pending writes, and report any error
+// raised while doing so.  Closing it more than once is a no-op.
+func (ds DataSource) Close() error {
+	return ds.own.close(func() error { return destroyDataSource(ds.cval) })
 }
 
 // Return the number of opened data sources
@@ -1571,47 +1991,55 @@ func OpenDataSourceCount() int {
 // Return the i'th datasource opened
 func OpenDataSourceByIndex(index int) DataSource {
 	ds := C.OGRGetOpenDS(C.int(index))
-	return DataSource{ds}
+	return DataSource{cval: ds}
 }
 
 // Closes datasource and releases resources
 func (ds DataSource) Destroy() {
-	C.OGR_DS_Destroy(ds.cval)
+	ds.Close()
 }
 
 // Fetch the name of the data source
 func (ds DataSource) Name() string {
+	ds.check()
 	name := C.OGR_DS_GetName(ds.cval)
 	return C.GoString(name)
 }
 
 // Fetch the number of layers in this data source
 func (ds DataSource) LayerCount() int {
+	ds.check()
 	count := C.OGR_DS_GetLayerCount(ds.cval)
 	return int(count)
 }
 
 // Fetch a layer of this data source by index
 func (ds DataSource) LayerByIndex(index int) Layer {
+	ds.check()
 	layer := C.OGR_DS_GetLayer(ds.cval, C.int(index))
-	return Layer{layer}
+	return Layer{layer, ds.own}
 }
 
 // Fetch a layer of this data source by name
 func (ds DataSource) LayerByName(name string) Layer {
+	ds.check()
 	cString := C.CString(name)
 	defer C.free(unsafe.Pointer(cString))
 	layer := C.OGR_DS_GetLayerByName(ds.cval, cString)
-	return Layer{layer}
+	return Layer{layer, ds.own}
 }
 
 // Delete the layer from the data source
 func (ds DataSource) Delete(index int) error {
-	return C.OGR_DS_DeleteLayer(ds.cval, C.int(index)).Err()
+	ds.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_DS_DeleteLayer(ds.cval, C.int(index))
+	})
 }
 
 // Fetch the driver that the data source was opened with
 func (ds DataSource) Driver() OGRDriver {
+	ds.check()
 	driver := C.OGR_DS_GetDriver(ds.cval)
 	return OGRDriver{driver}
 }
@@ -1623,6 +2051,7 @@ func (ds DataSource) CreateLayer(
 	geomType GeometryType,
 	options []string,
 ) Layer {
+	ds.check()
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
@@ -1641,7 +2070,7 @@ func (ds DataSource) CreateLayer(
 		C.OGRwkbGeometryType(geomType),
 		(**C.char)(unsafe.Pointer(&opts[0])),
 	)
-	return Layer{layer}
+	return Layer{layer, ds.own}
 }
 
 // Duplicate an existing layer
@@ -1650,6 +2079,7 @@ func (ds DataSource) CopyLayer(
 	name string,
 	options []string,
 ) Layer {
+	ds.check()
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
@@ -1667,11 +2097,12 @@ func (ds DataSource) CopyLayer(
 		cName,
 		(**C.char)(unsafe.Pointer(&opts[0])),
 	)
-	return Layer{layer}
+	return Layer{layer, ds.own}
 }
 
 // Test if the data source has the indicated capability
 func (ds DataSource) TestCapability(capability string) bool {
+	ds.check()
 	cString := C.CString(capability)
 	defer C.free(unsafe.Pointer(cString))
 	val := C.OGR_DS_TestCapability(ds.cval, cString)
@@ -1680,23 +2111,28 @@ func (ds DataSource) TestCapability(capability string) bool {
 
 // Execute an SQL statement against the data source
 func (ds DataSource) ExecuteSQL(sql string, filter Geometry, dialect string) Layer {
+	ds.check()
 	cSQL := C.CString(sql)
 	defer C.free(unsafe.Pointer(cSQL))
 	cDialect := C.CString(dialect)
 	defer C.free(unsafe.Pointer(cDialect))
 
 	layer := C.OGR_DS_ExecuteSQL(ds.cval, cSQL, filter.cval, cDialect)
-	return Layer{layer}
+	return Layer{layer, ds.own}
 }
 
 // Release the results of ExecuteSQL
 func (ds DataSource) ReleaseResultSet(layer Layer) {
+	ds.check()
 	C.OGR_DS_ReleaseResultSet(ds.cval, layer.cval)
 }
 
 // Flush pending changes to the data source
 func (ds DataSource) Sync() error {
-	return C.OGR_DS_SyncToDisk(ds.cval).Err()
+	ds.check()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_DS_SyncToDisk(ds.cval)
+	})
 }
 
 /* -------------------------------------------------------------------- */
@@ -1718,7 +2154,7 @@ func (driver OGRDriver) Open(filename string, update int) (newDS DataSource, ok
 	cFilename := C.CString(filename)
 	defer C.free(unsafe.Pointer(cFilename))
 	ds := C.OGR_Dr_Open(driver.cval, cFilename, C.int(update))
-	return DataSource{ds}, ds != nil
+	return ownDataSource(ds), ds != nil
 }
 
 // Test if this driver supports the named capability
@@ -1743,7 +2179,7 @@ func (driver OGRDriver) Create(name string, options []string) (newDS DataSource,
 	opts[length] = (*C.char)(unsafe.Pointer(nil))
 
 	ds := C.OGR_Dr_CreateDataSource(driver.cval, cName, (**C.char)(unsafe.Pointer(&opts[0])))
-	return DataSource{ds}, ds != nil
+	return ownDataSource(ds), ds != nil
 }
 
 // Create a new datasource with this driver by copying all layers of the existing datasource
@@ -1760,14 +2196,16 @@ func (driver OGRDriver) Copy(source DataSource, name string, options []string) (
 	opts[length] = (*C.char)(unsafe.Pointer(nil))
 
 	ds := C.OGR_Dr_CopyDataSource(driver.cval, source.cval, cName, (**C.char)(unsafe.Pointer(&opts[0])))
-	return DataSource{ds}, ds != nil
+	return ownDataSource(ds), ds != nil
 }
 
 // Delete a data source
 func (driver OGRDriver) Delete(filename string) error {
 	cFilename := C.CString(filename)
 	defer C.free(unsafe.Pointer(cFilename))
-	return C.OGR_Dr_DeleteDataSource(driver.cval, cFilename).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OGR_Dr_DeleteDataSource(driver.cval, cFilename)
+	})
 }
 
 // Add a driver to the list of registered drivers
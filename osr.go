@@ -22,6 +22,7 @@ import (
 
 type SpatialReference struct {
 	cval C.OGRSpatialReferenceH
+	own  *owner
 }
 
 // Create a new SpatialReference
@@ -29,20 +30,24 @@ func CreateSpatialReference(wkt string) SpatialReference {
 	cString := C.CString(wkt)
 	defer C.free(unsafe.Pointer(cString))
 	sr := C.OSRNewSpatialReference(cString)
-	return SpatialReference{sr}
+	return ownSpatialReference(sr)
 }
 
 // Initialize SRS based on WKT string
 func (sr SpatialReference) FromWKT(wkt string) error {
 	cString := C.CString(wkt)
 	defer C.free(unsafe.Pointer(cString))
-	return C.OSRImportFromWkt(sr.cval, &cString).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRImportFromWkt(sr.cval, &cString)
+	})
 }
 
 // Export coordinate system to WKT
 func (sr SpatialReference) ToWKT() (string, error) {
 	var p *C.char
-	err := C.OSRExportToWkt(sr.cval, &p).Err()
+	err := ogrCall(func() C.OGRErr {
+		return C.OSRExportToWkt(sr.cval, &p)
+	})
 	wkt := C.GoString(p)
 	return wkt, err
 }
@@ -50,38 +55,71 @@ func (sr SpatialReference) ToWKT() (string, error) {
 // Export coordinate system to a nicely formatted WKT string
 func (sr SpatialReference) ToPrettyWKT(simplify bool) (string, error) {
 	var p *C.char
-	err := C.OSRExportToPrettyWkt(
-		sr.cval, &p, BoolToCInt(simplify),
-	).Err()
+	err := ogrCall(func() C.OGRErr {
+		return C.OSRExportToPrettyWkt(
+			sr.cval, &p, BoolToCInt(simplify),
+		)
+	})
 	wkt := C.GoString(p)
 	return wkt, err
 }
 
 // Initialize SRS based on EPSG code
 func (sr SpatialReference) FromEPSG(code int) error {
-	return C.OSRImportFromEPSG(sr.cval, C.int(code)).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRImportFromEPSG(sr.cval, C.int(code))
+	})
 }
 
 // Initialize SRS based on EPSG code, using EPSG lat/long ordering
 func (sr SpatialReference) FromEPSGA(code int) error {
-	return C.OSRImportFromEPSGA(sr.cval, C.int(code)).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRImportFromEPSGA(sr.cval, C.int(code))
+	})
+}
+
+// Take ownership of a spatial reference handle
+func ownSpatialReference(h C.OGRSpatialReferenceH) SpatialReference {
+	if h == nil {
+		return SpatialReference{}
+	}
+	return SpatialReference{h, newOwner("SpatialReference", func() error { return releaseSpatialReference(h) })}
+}
+
+func releaseSpatialReference(h C.OGRSpatialReferenceH) error {
+	if h != nil {
+		C.OSRRelease(h)
+	}
+	return nil
 }
 
 // Destroy the spatial reference
 func (sr SpatialReference) Destroy() {
-	C.OSRDestroySpatialReference(sr.cval)
+	sr.own.close(func() error {
+		if sr.cval != nil {
+			C.OSRDestroySpatialReference(sr.cval)
+		}
+		return nil
+	})
+}
+
+// Drop the reference held by a spatial reference created by this package;
+// the object is deleted once nothing else refers to it.  Closing it more
+// than once is a no-op.
+func (sr SpatialReference) Close() error {
+	return sr.own.close(func() error { return releaseSpatialReference(sr.cval) })
 }
 
 // Make a duplicate of this spatial reference
 func (sr SpatialReference) Clone() SpatialReference {
 	newSR := C.OSRClone(sr.cval)
-	return SpatialReference{newSR}
+	return ownSpatialReference(newSR)
 }
 
 // Make a duplicate of the GEOGCS node of this spatial reference
 func (sr SpatialReference) CloneGeogCS() SpatialReference {
 	newSR := C.OSRCloneGeogCS(sr.cval)
-	return SpatialReference{newSR}
+	return ownSpatialReference(newSR)
 }
 
 // Increments the reference count by one, returning reference count
@@ -96,42 +134,56 @@ func (sr SpatialReference) Dereference() int {
 	return int(count)
 }
 
-// Decrements the reference count by one and destroy if zero
+// Decrements the reference count by one and destroy if zero.  Each call
+// drops one reference, such as one taken with Reference; the reference
+// held by the value itself is dropped by Close.
 func (sr SpatialReference) Release() {
 	C.OSRRelease(sr.cval)
 }
 
 // Validate spatial reference tokens
 func (sr SpatialReference) Validate() error {
-	return C.OSRValidate(sr.cval).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRValidate(sr.cval)
+	})
 }
 
 // Correct parameter ordering to match CT specification
 func (sr SpatialReference) FixupOrdering() error {
-	return C.OSRFixupOrdering(sr.cval).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRFixupOrdering(sr.cval)
+	})
 }
 
 // Fix up spatial reference as needed
 func (sr SpatialReference) Fixup() error {
-	return C.OSRFixup(sr.cval).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRFixup(sr.cval)
+	})
 }
 
 // Strip OGC CT parameters
 func (sr SpatialReference) StripCTParams() error {
-	return C.OSRStripCTParms(sr.cval).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRStripCTParms(sr.cval)
+	})
 }
 
 // Import PROJ.4 coordinate string
 func (sr SpatialReference) FromProj4(input string) error {
 	cString := C.CString(input)
 	defer C.free(unsafe.Pointer(cString))
-	return C.OSRImportFromProj4(sr.cval, cString).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRImportFromProj4(sr.cval, cString)
+	})
 }
 
 // Export coordinate system in PROJ.4 format
 func (sr SpatialReference) ToProj4() (string, error) {
 	var p *C.char
-	err := C.OSRExportToProj4(sr.cval, &p).Err()
+	err := ogrCall(func() C.OGRErr {
+		return C.OSRExportToProj4(sr.cval, &p)
+	})
 	proj4 := C.GoString(p)
 	return proj4, err
 }
@@ -140,7 +192,9 @@ func (sr SpatialReference) ToProj4() (string, error) {
 func (sr SpatialReference) FromESRI(input string) error {
 	cString := C.CString(input)
 	defer C.free(unsafe.Pointer(cString))
-	return C.OSRImportFromProj4(sr.cval, cString).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRImportFromProj4(sr.cval, cString)
+	})
 }
 
 // Import coordinate system from PCI projection definition
@@ -150,30 +204,36 @@ func (sr SpatialReference) FromPCI(proj, units string, params []float64) error {
 	cUnits := C.CString(units)
 	defer C.free(unsafe.Pointer(cUnits))
 
-	return C.OSRImportFromPCI(
-		sr.cval,
-		cProj,
-		cUnits,
-		(*C.double)(unsafe.Pointer(&params[0])),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRImportFromPCI(
+			sr.cval,
+			cProj,
+			cUnits,
+			(*C.double)(unsafe.Pointer(&params[0])),
+		)
+	})
 }
 
 // Import coordinate system from USGS projection definition
 func (sr SpatialReference) FromUSGS(projsys, zone int, params []float64, datum int) error {
-	return C.OSRImportFromUSGS(
-		sr.cval,
-		C.long(projsys),
-		C.long(zone),
-		(*C.double)(unsafe.Pointer(&params[0])),
-		C.long(datum),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRImportFromUSGS(
+			sr.cval,
+			C.long(projsys),
+			C.long(zone),
+			(*C.double)(unsafe.Pointer(&params[0])),
+			C.long(datum),
+		)
+	})
 }
 
 // Import coordinate system from XML format (GML only currently)
 func (sr SpatialReference) FromXML(xml string) error {
 	cXml := C.CString(xml)
 	defer C.free(unsafe.Pointer(cXml))
-	return C.OSRImportFromXML(sr.cval, cXml).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRImportFromXML(sr.cval, cXml)
+	})
 }
 
 // Import coordinate system from ERMapper projection definitions
@@ -185,22 +245,28 @@ func (sr SpatialReference) FromERM(proj, datum, units string) error {
 	cUnits := C.CString(units)
 	defer C.free(unsafe.Pointer(cUnits))
 
-	return C.OSRImportFromERM(sr.cval, cProj, cDatum, cUnits).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRImportFromERM(sr.cval, cProj, cDatum, cUnits)
+	})
 }
 
 // Import coordinate system from a URL
 func (sr SpatialReference) FromURL(url string) error {
 	cURL := C.CString(url)
 	defer C.free(unsafe.Pointer(cURL))
-	return C.OSRImportFromXML(sr.cval, cURL).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRImportFromXML(sr.cval, cURL)
+	})
 }
 
 // Export coordinate system in PCI format
 func (sr SpatialReference) ToPCI() (proj, units string, params []float64, errVal error) {
 	var p, u *C.char
-	err := C.OSRExportToPCI(
-		sr.cval, &p, &u, (**C.double)(unsafe.Pointer(&params[0])),
-	).Err()
+	err := ogrCall(func() C.OGRErr {
+		return C.OSRExportToPCI(
+			sr.cval, &p, &u, (**C.double)(unsafe.Pointer(&params[0])),
+		)
+	})
 	header := (*reflect.SliceHeader)(unsafe.Pointer(&params))
 	header.Cap = 17
 	header.Len = 17
@@ -211,13 +277,15 @@ func (sr SpatialReference) ToPCI() (proj, units string, params []float64, errVal
 
 // Export coordinate system to USGS GCTP projection definition
 func (sr SpatialReference) ToUSGS() (proj, zone int, params []float64, datum int, errVal error) {
-	err := C.OSRExportToUSGS(
-		sr.cval,
-		(*C.long)(unsafe.Pointer(&proj)),
-		(*C.long)(unsafe.Pointer(&zone)),
-		(**C.double)(unsafe.Pointer(&params[0])),
-		(*C.long)(unsafe.Pointer(&datum)),
-	).Err()
+	err := ogrCall(func() C.OGRErr {
+		return C.OSRExportToUSGS(
+			sr.cval,
+			(*C.long)(unsafe.Pointer(&proj)),
+			(*C.long)(unsafe.Pointer(&zone)),
+			(**C.double)(unsafe.Pointer(&params[0])),
+			(*C.long)(unsafe.Pointer(&datum)),
+		)
+	})
 
 	header := (*reflect.SliceHeader)(unsafe.Pointer(&params))
 	header.Cap = 15
@@ -229,7 +297,9 @@ func (sr SpatialReference) ToUSGS() (proj, zone int, params []float64, datum int
 // Export coordinate system in XML format
 func (sr SpatialReference) ToXML() (xml string, errVal error) {
 	var x *C.char
-	err := C.OSRExportToXML(sr.cval, &x, nil).Err()
+	err := ogrCall(func() C.OGRErr {
+		return C.OSRExportToXML(sr.cval, &x, nil)
+	})
 	defer C.free(unsafe.Pointer(x))
 	return C.GoString(x), err
 }
@@ -237,7 +307,9 @@ func (sr SpatialReference) ToXML() (xml string, errVal error) {
 // Export coordinate system in Mapinfo style CoordSys format
 func (sr SpatialReference) ToMICoordSys() (output string, errVal error) {
 	var x *C.char
-	err := C.OSRExportToMICoordSys(sr.cval, &x).Err()
+	err := ogrCall(func() C.OGRErr {
+		return C.OSRExportToMICoordSys(sr.cval, &x)
+	})
 	defer C.free(unsafe.Pointer(x))
 	return C.GoString(x), err
 }
@@ -247,12 +319,16 @@ func (sr SpatialReference) ToMICoordSys() (output string, errVal error) {
 
 // Convert in place to ESRI WKT format
 func (sr SpatialReference) MorphToESRI() error {
-	return C.OSRMorphToESRI(sr.cval).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRMorphToESRI(sr.cval)
+	})
 }
 
 // Convert in place from ESRI WKT format
 func (sr SpatialReference) MorphFromESRI() error {
-	return C.OSRMorphFromESRI(sr.cval).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRMorphFromESRI(sr.cval)
+	})
 }
 
 // Fetch indicated attribute of named node
@@ -269,14 +345,18 @@ func (sr SpatialReference) SetAttrValue(path, value string) error {
 	defer C.free(unsafe.Pointer(cPath))
 	cValue := C.CString(value)
 	defer C.free(unsafe.Pointer(cValue))
-	return C.OSRSetAttrValue(sr.cval, cPath, cValue).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetAttrValue(sr.cval, cPath, cValue)
+	})
 }
 
 // Set the angular units for the geographic coordinate system
 func (sr SpatialReference) SetAngularUnits(units string, radians float64) error {
 	cUnits := C.CString(units)
 	defer C.free(unsafe.Pointer(cUnits))
-	return C.OSRSetAngularUnits(sr.cval, cUnits, C.double(radians)).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetAngularUnits(sr.cval, cUnits, C.double(radians))
+	})
 }
 
 // Fetch the angular units for the geographic coordinate system
@@ -291,7 +371,9 @@ func (sr SpatialReference) AngularUnits() (string, float64) {
 func (sr SpatialReference) SetLinearUnits(name string, toMeters float64) error {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	return C.OSRSetLinearUnits(sr.cval, cName, C.double(toMeters)).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetLinearUnits(sr.cval, cName, C.double(toMeters))
+	})
 }
 
 // Set the linear units for the target node
@@ -300,14 +382,18 @@ func (sr SpatialReference) SetTargetLinearUnits(target, units string, toMeters f
 	defer C.free(unsafe.Pointer(cTarget))
 	cUnits := C.CString(units)
 	defer C.free(unsafe.Pointer(cUnits))
-	return C.OSRSetTargetLinearUnits(sr.cval, cTarget, cUnits, C.double(toMeters)).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetTargetLinearUnits(sr.cval, cTarget, cUnits, C.double(toMeters))
+	})
 }
 
 // Set the linear units for the target node and update all existing linear parameters
 func (sr SpatialReference) SetLinearUnitsAndUpdateParameters(name string, toMeters float64) error {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	return C.OSRSetLinearUnitsAndUpdateParameters(sr.cval, cName, C.double(toMeters)).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetLinearUnitsAndUpdateParameters(sr.cval, cName, C.double(toMeters))
+	})
 }
 
 // Fetch linear projection units
@@ -394,59 +480,75 @@ func (sr SpatialReference) IsSame(other SpatialReference) bool {
 func (sr SpatialReference) SetLocalCS(name string) error {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	return C.OSRSetLocalCS(sr.cval, cName).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetLocalCS(sr.cval, cName)
+	})
 }
 
 // Set the user visible projected CS name
 func (sr SpatialReference) SetProjectedCS(name string) error {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	return C.OSRSetProjCS(sr.cval, cName).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetProjCS(sr.cval, cName)
+	})
 }
 
 // Set the user visible geographic CS name
 func (sr SpatialReference) SetGeocentricCS(name string) error {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	return C.OSRSetGeocCS(sr.cval, cName).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetGeocCS(sr.cval, cName)
+	})
 }
 
 // Set geographic CS based on well known name
 func (sr SpatialReference) SetWellKnownGeographicCS(name string) error {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	return C.OSRSetWellKnownGeogCS(sr.cval, cName).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetWellKnownGeogCS(sr.cval, cName)
+	})
 }
 
 // Set spatial reference from various text formats
 func (sr SpatialReference) SetFromUserInput(name string) error {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	return C.OSRSetFromUserInput(sr.cval, cName).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetFromUserInput(sr.cval, cName)
+	})
 }
 
 // Copy geographic CS from another spatial reference
 func (sr SpatialReference) CopyGeographicCSFrom(other SpatialReference) error {
-	return C.OSRCopyGeogCSFrom(sr.cval, other.cval).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRCopyGeogCSFrom(sr.cval, other.cval)
+	})
 }
 
 // Set the Bursa-Wolf conversion to WGS84
 func (sr SpatialReference) SetTOWGS84(dx, dy, dz, ex, ey, ez, ppm float64) error {
-	return C.OSRSetTOWGS84(
-		sr.cval,
-		C.double(dx),
-		C.double(dy),
-		C.double(dz),
-		C.double(ex),
-		C.double(ey),
-		C.double(ez),
-		C.double(ppm),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetTOWGS84(
+			sr.cval,
+			C.double(dx),
+			C.double(dy),
+			C.double(dz),
+			C.double(ex),
+			C.double(ey),
+			C.double(ez),
+			C.double(ppm),
+		)
+	})
 }
 
 // Fetch the TOWGS84 parameters if available
 func (sr SpatialReference) TOWGS84() (coeff [7]float64, errVal error) {
-	err := C.OSRGetTOWGS84(sr.cval, (*C.double)(unsafe.Pointer(&coeff[0])), 7).Err()
+	err := ogrCall(func() C.OGRErr {
+		return C.OSRGetTOWGS84(sr.cval, (*C.double)(unsafe.Pointer(&coeff[0])), 7)
+	})
 	return coeff, error(err)
 }
 
@@ -457,7 +559,9 @@ func (sr SpatialReference) SetCompoundCS(
 ) error {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	return C.OSRSetCompoundCS(sr.cval, cName, horizontal.cval, vertical.cval).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetCompoundCS(sr.cval, cName, horizontal.cval, vertical.cval)
+	})
 }
 
 // Set geographic coordinate system
@@ -479,18 +583,20 @@ func (sr SpatialReference) SetGeographicCS(
 	defer C.free(unsafe.Pointer(cPMName))
 	cAngularUnits := C.CString(angularUnits)
 	defer C.free(unsafe.Pointer(cAngularUnits))
-	return C.OSRSetGeogCS(
-		sr.cval,
-		cGeogName,
-		cDatumName,
-		cSpheroidName,
-		C.double(semiMajor),
-		C.double(flattening),
-		cPMName,
-		C.double(offset),
-		cAngularUnits,
-		C.double(toRadians),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetGeogCS(
+			sr.cval,
+			cGeogName,
+			cDatumName,
+			cSpheroidName,
+			C.double(semiMajor),
+			C.double(flattening),
+			cPMName,
+			C.double(offset),
+			cAngularUnits,
+			C.double(toRadians),
+		)
+	})
 }
 
 // Set up the vertical coordinate system
@@ -499,28 +605,39 @@ func (sr SpatialReference) SetVerticalCS(csName, datumName string, datumType int
 	defer C.free(unsafe.Pointer(cCSName))
 	cDatumName := C.CString(datumName)
 	defer C.free(unsafe.Pointer(cDatumName))
-	return C.OSRSetVertCS(sr.cval, cCSName, cDatumName, C.int(datumType)).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetVertCS(sr.cval, cCSName, cDatumName, C.int(datumType))
+	})
 }
 
 // Get spheroid semi-major axis
 func (sr SpatialReference) SemiMajorAxis() (float64, error) {
 	var cErr C.OGRErr
-	axis := C.OSRGetSemiMajor(sr.cval, &cErr)
-	return float64(axis), cErr.Err()
+	var axis C.double
+	messages := captureErrors(func() {
+		axis = C.OSRGetSemiMajor(sr.cval, &cErr)
+	})
+	return float64(axis), ogrError(cErr, messages)
 }
 
 // Get spheroid semi-minor axis
 func (sr SpatialReference) SemiMinorAxis() (float64, error) {
 	var cErr C.OGRErr
-	axis := C.OSRGetSemiMinor(sr.cval, &cErr)
-	return float64(axis), cErr.Err()
+	var axis C.double
+	messages := captureErrors(func() {
+		axis = C.OSRGetSemiMinor(sr.cval, &cErr)
+	})
+	return float64(axis), ogrError(cErr, messages)
 }
 
 // Get spheroid inverse flattening axis
 func (sr SpatialReference) InverseFlattening() (float64, error) {
 	var cErr C.OGRErr
-	flat := C.OSRGetInvFlattening(sr.cval, &cErr)
-	return float64(flat), cErr.Err()
+	var flat C.double
+	messages := captureErrors(func() {
+		flat = C.OSRGetInvFlattening(sr.cval, &cErr)
+	})
+	return float64(flat), ogrError(cErr, messages)
 }
 
 // Sets the authority for a node
@@ -529,7 +646,9 @@ func (sr SpatialReference) SetAuthority(target, authority string, code int) erro
 	defer C.free(unsafe.Pointer(cTarget))
 	cAuthority := C.CString(authority)
 	defer C.free(unsafe.Pointer(cAuthority))
-	return C.OSRSetAuthority(sr.cval, cTarget, cAuthority, C.int(code)).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetAuthority(sr.cval, cTarget, cAuthority, C.int(code))
+	})
 }
 
 // Get the authority code for a node
@@ -552,14 +671,18 @@ func (sr SpatialReference) AuthorityName(target string) string {
 func (sr SpatialReference) SetProjectionByName(name string) error {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	return C.OSRSetProjection(sr.cval, cName).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetProjection(sr.cval, cName)
+	})
 }
 
 // Set a projection parameter value
 func (sr SpatialReference) SetProjectionParameter(name string, value float64) error {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	return C.OSRSetProjParm(sr.cval, cName, C.double(value)).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetProjParm(sr.cval, cName, C.double(value))
+	})
 }
 
 // Fetch a projection parameter value
@@ -567,15 +690,20 @@ func (sr SpatialReference) ProjectionParameter(name string, defaultValue float64
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 	var cErr C.OGRErr
-	value := C.OSRGetProjParm(sr.cval, cName, C.double(defaultValue), &cErr)
-	return float64(value), cErr.Err()
+	var value C.double
+	messages := captureErrors(func() {
+		value = C.OSRGetProjParm(sr.cval, cName, C.double(defaultValue), &cErr)
+	})
+	return float64(value), ogrError(cErr, messages)
 }
 
 // Set a projection parameter with a normalized value
 func (sr SpatialReference) SetNormalizedProjectionParameter(name string, value float64) error {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	return C.OSRSetNormProjParm(sr.cval, cName, C.double(value)).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetNormProjParm(sr.cval, cName, C.double(value))
+	})
 }
 
 // Fetch a normalized projection parameter value
@@ -585,13 +713,18 @@ func (sr SpatialReference) NormalizedProjectionParameter(
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 	var cErr C.OGRErr
-	value := C.OSRGetProjParm(sr.cval, cName, C.double(defaultValue), &cErr)
-	return float64(value), cErr.Err()
+	var value C.double
+	messages := captureErrors(func() {
+		value = C.OSRGetProjParm(sr.cval, cName, C.double(defaultValue), &cErr)
+	})
+	return float64(value), ogrError(cErr, messages)
 }
 
 // Set UTM projection definition
 func (sr SpatialReference) SetUTM(zone int, north bool) error {
-	return C.OSRSetUTM(sr.cval, C.int(zone), BoolToCInt(north)).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetUTM(sr.cval, C.int(zone), BoolToCInt(north))
+	})
 }
 
 // Get UTM zone information
@@ -603,7 +736,9 @@ func (sr SpatialReference) UTMZone() (zone int, north bool) {
 
 // Set State Plane projection definition
 func (sr SpatialReference) SetStatePlane(zone int, nad83 bool) error {
-	return C.OSRSetStatePlane(sr.cval, C.int(zone), BoolToCInt(nad83)).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetStatePlane(sr.cval, C.int(zone), BoolToCInt(nad83))
+	})
 }
 
 // Set State Plane projection definition
@@ -615,18 +750,22 @@ func (sr SpatialReference) SetStatePlaneWithUnits(
 ) error {
 	cUnitName := C.CString(unitName)
 	defer C.free(unsafe.Pointer(cUnitName))
-	return C.OSRSetStatePlaneWithUnits(
-		sr.cval,
-		C.int(zone),
-		BoolToCInt(nad83),
-		cUnitName,
-		C.double(factor),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetStatePlaneWithUnits(
+			sr.cval,
+			C.int(zone),
+			BoolToCInt(nad83),
+			cUnitName,
+			C.double(factor),
+		)
+	})
 }
 
 // Set EPSG authority info if possible
 func (sr SpatialReference) AutoIdentifyEPSG() error {
-	return C.OSRAutoIdentifyEPSG(sr.cval).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRAutoIdentifyEPSG(sr.cval)
+	})
 }
 
 // Return true if EPSG feels this coordinate system should be treated as having lat/long coordinate ordering
@@ -642,468 +781,540 @@ func (sr SpatialReference) EPSGTreatsAsLatLong() bool {
 func (sr SpatialReference) SetACEA(
 	stdp1, stdp2, centerLat, centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetACEA(
-		sr.cval,
-		C.double(stdp1),
-		C.double(stdp2),
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetACEA(
+			sr.cval,
+			C.double(stdp1),
+			C.double(stdp2),
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Azimuthal Equidistant
 func (sr SpatialReference) SetAE(centerLat, centerLong, falseEasting, falseNorthing float64) error {
-	return C.OSRSetAE(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetAE(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Bonne
 func (sr SpatialReference) SetBonne(standardParallel, centralMeridian, falseEasting, falseNorthing float64) error {
-	return C.OSRSetBonne(
-		sr.cval,
-		C.double(standardParallel),
-		C.double(centralMeridian),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetBonne(
+			sr.cval,
+			C.double(standardParallel),
+			C.double(centralMeridian),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Cylindrical Equal Area
 func (sr SpatialReference) SetCEA(stdp1, centralMeridian, falseEasting, falseNorthing float64) error {
-	return C.OSRSetCEA(
-		sr.cval,
-		C.double(stdp1),
-		C.double(centralMeridian),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetCEA(
+			sr.cval,
+			C.double(stdp1),
+			C.double(centralMeridian),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Cassini-Soldner
 func (sr SpatialReference) SetCS(centerLat, centerLong, falseEasting, falseNorthing float64) error {
-	return C.OSRSetCS(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetCS(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Equidistant Conic
 func (sr SpatialReference) SetEC(
 	stdp1, stdp2, centerLat, centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetEC(
-		sr.cval,
-		C.double(stdp1),
-		C.double(stdp2),
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetEC(
+			sr.cval,
+			C.double(stdp1),
+			C.double(stdp2),
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Eckert I-VI
 func (sr SpatialReference) SetEckert(variation int, centralMeridian, falseEasting, falseNorthing float64) error {
-	return C.OSRSetEckert(
-		sr.cval,
-		C.int(variation),
-		C.double(centralMeridian),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetEckert(
+			sr.cval,
+			C.int(variation),
+			C.double(centralMeridian),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Equirectangular
 func (sr SpatialReference) SetEquirectangular(
 	centerLat, centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetEquirectangular(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetEquirectangular(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Equirectangular (generalized form)
 func (sr SpatialReference) SetEquirectangularGeneralized(
 	centerLat, centerLong, psuedoStdParallel, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetEquirectangular2(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(psuedoStdParallel),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetEquirectangular2(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(psuedoStdParallel),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Gall Stereographic
 func (sr SpatialReference) SetGS(centralMeridian, falseEasting, falseNorthing float64) error {
-	return C.OSRSetGS(
-		sr.cval,
-		C.double(centralMeridian),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetGS(
+			sr.cval,
+			C.double(centralMeridian),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Goode Homolosine
 func (sr SpatialReference) SetGH(centralMeridian, falseEasting, falseNorthing float64) error {
-	return C.OSRSetGH(
-		sr.cval,
-		C.double(centralMeridian),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetGH(
+			sr.cval,
+			C.double(centralMeridian),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Interrupted Goode Homolosine
 func (sr SpatialReference) SetIGH() error {
-	return C.OSRSetIGH(sr.cval).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetIGH(sr.cval)
+	})
 }
 
 // Set to GEOS - Geostationary Satellite View
 func (sr SpatialReference) SetGEOS(
 	centralMeridian, satelliteHeight, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetGEOS(
-		sr.cval,
-		C.double(centralMeridian),
-		C.double(satelliteHeight),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetGEOS(
+			sr.cval,
+			C.double(centralMeridian),
+			C.double(satelliteHeight),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Gauss Schreiber Transverse Mercator
 func (sr SpatialReference) SetGSTM(
 	centerLat, centerLong, scale, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetGaussSchreiberTMercator(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(scale),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetGaussSchreiberTMercator(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(scale),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to gnomonic
 func (sr SpatialReference) SetGnomonic(
 	centerLat, centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetGnomonic(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetGnomonic(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Hotine Oblique Mercator projection using azimuth angle
 func (sr SpatialReference) SetHOM(
 	centerLat, centerLong, azimuth, rectToSkew, scale, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetHOM(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(azimuth),
-		C.double(rectToSkew),
-		C.double(scale),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetHOM(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(azimuth),
+			C.double(rectToSkew),
+			C.double(scale),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Hotine Oblique Mercator projection using two points on projection centerline
 func (sr SpatialReference) SetHOM2PNO(
 	centerLat, lat1, long1, lat2, long2, scale, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetHOM2PNO(
-		sr.cval,
-		C.double(centerLat),
-		C.double(lat1),
-		C.double(long1),
-		C.double(lat2),
-		C.double(long2),
-		C.double(scale),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetHOM2PNO(
+			sr.cval,
+			C.double(centerLat),
+			C.double(lat1),
+			C.double(long1),
+			C.double(lat2),
+			C.double(long2),
+			C.double(scale),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to International Map of the World Polyconic
 func (sr SpatialReference) SetIWMPolyconic(
 	lat1, lat2, centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetIWMPolyconic(
-		sr.cval,
-		C.double(lat1),
-		C.double(lat2),
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetIWMPolyconic(
+			sr.cval,
+			C.double(lat1),
+			C.double(lat2),
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Krovak Oblique Conic Conformal
 func (sr SpatialReference) SetKrovak(
 	centerLat, centerLong, azimuth, psuedoStdParallel, scale, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetKrovak(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(azimuth),
-		C.double(psuedoStdParallel),
-		C.double(scale),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetKrovak(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(azimuth),
+			C.double(psuedoStdParallel),
+			C.double(scale),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Lambert Azimuthal Equal Area
 func (sr SpatialReference) SetLAEA(
 	centerLat, centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetLAEA(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetLAEA(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Lambert Conformal Conic
 func (sr SpatialReference) SetLCC(
 	stdp1, stdp2, centerLat, centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetLCC(
-		sr.cval,
-		C.double(stdp1),
-		C.double(stdp2),
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetLCC(
+			sr.cval,
+			C.double(stdp1),
+			C.double(stdp2),
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Lambert Conformal Conic (1 standard parallel)
 func (sr SpatialReference) SetLCC1SP(
 	centerLat, centerLong, scale, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetLCC1SP(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(scale),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetLCC1SP(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(scale),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Lambert Conformal Conic (Belgium)
 func (sr SpatialReference) SetLCCB(
 	stdp1, stdp2, centerLat, centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetLCCB(
-		sr.cval,
-		C.double(stdp1),
-		C.double(stdp2),
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetLCCB(
+			sr.cval,
+			C.double(stdp1),
+			C.double(stdp2),
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Miller Cylindrical
 func (sr SpatialReference) SetMC(
 	centerLat, centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetMC(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetMC(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Mercator
 func (sr SpatialReference) SetMercator(
 	centerLat, centerLong, scale, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetMercator(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(scale),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetMercator(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(scale),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set tp Mollweide
 func (sr SpatialReference) SetMollweide(
 	centralMeridian, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetMollweide(
-		sr.cval,
-		C.double(centralMeridian),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetMollweide(
+			sr.cval,
+			C.double(centralMeridian),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to New Zealand Map Grid
 func (sr SpatialReference) SetNZMG(
 	centerLat, centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetNZMG(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetNZMG(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Oblique Stereographic
 func (sr SpatialReference) SetOS(
 	originLat, meridian, scale, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetOS(
-		sr.cval,
-		C.double(originLat),
-		C.double(meridian),
-		C.double(scale),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetOS(
+			sr.cval,
+			C.double(originLat),
+			C.double(meridian),
+			C.double(scale),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Orthographic
 func (sr SpatialReference) SetOrthographic(
 	centerLat, centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetOrthographic(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetOrthographic(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Polyconic
 func (sr SpatialReference) SetPolyconic(
 	centerLat, centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetPolyconic(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetPolyconic(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Polar Stereographic
 func (sr SpatialReference) SetPS(
 	centerLat, centerLong, scale, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetPS(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(scale),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetPS(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(scale),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Robinson
 func (sr SpatialReference) SetRobinson(
 	centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetRobinson(
-		sr.cval,
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetRobinson(
+			sr.cval,
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Sinusoidal
 func (sr SpatialReference) SetSinusoidal(
 	centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetSinusoidal(
-		sr.cval,
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetSinusoidal(
+			sr.cval,
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Stereographic
 func (sr SpatialReference) SetStereographic(
 	centerLat, centerLong, scale, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetStereographic(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(scale),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetStereographic(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(scale),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Swiss Oblique Cylindrical
 func (sr SpatialReference) SetSOC(
 	latitudeOfOrigin, centralMeridian, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetSOC(
-		sr.cval,
-		C.double(latitudeOfOrigin),
-		C.double(centralMeridian),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetSOC(
+			sr.cval,
+			C.double(latitudeOfOrigin),
+			C.double(centralMeridian),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Transverse Mercator
 func (sr SpatialReference) SetTM(
 	centerLat, centerLong, scale, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetTM(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(scale),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetTM(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(scale),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Transverse Mercator variant
@@ -1112,54 +1323,62 @@ func (sr SpatialReference) SetTMVariant(
 ) error {
 	cName := C.CString(variantName)
 	defer C.free(unsafe.Pointer(cName))
-	return C.OSRSetTMVariant(
-		sr.cval,
-		cName,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(scale),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetTMVariant(
+			sr.cval,
+			cName,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(scale),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Tunisia Mining Grid
 func (sr SpatialReference) SetTMG(
 	centerLat, centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetTMG(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetTMG(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to Transverse Mercator (South Oriented)
 func (sr SpatialReference) SetTMSO(
 	centerLat, centerLong, scale, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetTMSO(
-		sr.cval,
-		C.double(centerLat),
-		C.double(centerLong),
-		C.double(scale),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetTMSO(
+			sr.cval,
+			C.double(centerLat),
+			C.double(centerLong),
+			C.double(scale),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Set to VanDerGrinten
 func (sr SpatialReference) SetVDG(
 	centerLong, falseEasting, falseNorthing float64,
 ) error {
-	return C.OSRSetVDG(
-		sr.cval,
-		C.double(centerLong),
-		C.double(falseEasting),
-		C.double(falseNorthing),
-	).Err()
+	return ogrCall(func() C.OGRErr {
+		return C.OSRSetVDG(
+			sr.cval,
+			C.double(centerLong),
+			C.double(falseEasting),
+			C.double(falseNorthing),
+		)
+	})
 }
 
 // Cleanup cached SRS related memory
@@ -1173,6 +1392,7 @@ func CleanupSR() {
 
 type CoordinateTransform struct {
 	cval C.OGRCoordinateTransformationH
+	own  *owner
 }
 
 // Create a new CoordinateTransform
@@ -1181,12 +1401,32 @@ func CreateCoordinateTransform(
 	dest SpatialReference,
 ) CoordinateTransform {
 	ct := C.OCTNewCoordinateTransformation(source.cval, dest.cval)
-	return CoordinateTransform{ct}
+	return ownCoordinateTransform(ct)
+}
+
+// Take ownership of a coordinate transformation handle
+func ownCoordinateTransform(h C.OGRCoordinateTransformationH) CoordinateTransform {
+	if h == nil {
+		return CoordinateTransform{}
+	}
+	return CoordinateTransform{h, newOwner("CoordinateTransform", func() error { return destroyCoordinateTransform(h) })}
+}
+
+func destroyCoordinateTransform(h C.OGRCoordinateTransformationH) error {
+	if h != nil {
+		C.OCTDestroyCoordinateTransformation(h)
+	}
+	return nil
 }
 
 // Destroy CoordinateTransform
 func (ct CoordinateTransform) Destroy() {
-	C.OCTDestroyCoordinateTransformation(ct.cval)
+	ct.Close()
+}
+
+// Destroy CoordinateTransform.  Closing it more than once is a no-op.
+func (ct CoordinateTransform) Close() error {
+	return ct.own.close(func() error { return destroyCoordinateTransform(ct.cval) })
 }
 
 func (ct CoordinateTransform) Transform(numPoints int, xPoints []float64, yPoints []float64, zPoints []float64) bool {
@@ -0,0 +1,196 @@
+package gdal
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"runtime"
+	"sync"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Parallel block processing.                                      */
+/* -------------------------------------------------------------------- */
+
+// BandInput names a band of a raster file read by ProcessBlocks
+type BandInput struct {
+	Filename string
+	Band     int
+}
+
+// Options for ProcessBlocks
+type ProcessOptions struct {
+	// Number of worker goroutines, or 0 for runtime.GOMAXPROCS(0)
+	Workers int
+	// Progress callback, called after each block is written
+	Progress     ProgressFunc
+	ProgressData interface{}
+}
+
+// Compute the output band block by block from the input bands, spreading
+// blocks across worker goroutines.  GDAL handles must not be shared between
+// threads, so each worker opens the input files itself; the output band is
+// only written from the calling goroutine.
+//
+// For each natural block of output, fn is called with the pixels of every
+// input band over the block window, in the order of inputs, and fills out
+// with the output pixels.  All input bands must have the size of the
+// output band.
+//
+// Processing stops at the first error returned by fn or raised reading or
+// writing pixels, when ctx is done, or when the progress callback returns
+// 0.
+func ProcessBlocks[T Pixel](
+	ctx context.Context,
+	inputs []BandInput,
+	output RasterBand,
+	fn func(in [][]T, out []T, window image.Rectangle) error,
+	options ProcessOptions,
+) error {
+	output.check()
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	xSize, ySize := output.XSize(), output.YSize()
+	windows := blockWindows(output)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		window image.Rectangle
+		data   []T
+		err    error
+	}
+	jobs := make(chan image.Rectangle)
+	results := make(chan result)
+	send := func(r result) bool {
+		select {
+		case results <- r:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bands, closeInputs, err := openInputs(inputs, xSize, ySize)
+			if err != nil {
+				send(result{err: err})
+				return
+			}
+			defer closeInputs()
+
+			for window := range jobs {
+				w, h := window.Dx(), window.Dy()
+				in := make([][]T, len(bands))
+				for j, band := range bands {
+					in[j], err = ReadWindow[T](band, window.Min.X, window.Min.Y, w, h)
+					if err != nil {
+						send(result{err: err})
+						return
+					}
+				}
+				out := make([]T, w*h)
+				if err := fn(in, out, window); err != nil {
+					send(result{err: err})
+					return
+				}
+				if !send(result{window: window, data: out}) {
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for _, window := range windows {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case jobs <- window:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var err error
+	written := 0
+	for r := range results {
+		if err != nil {
+			continue
+		}
+		err = r.err
+		if err == nil {
+			w, h := r.window.Dx(), r.window.Dy()
+			err = WriteWindow(output, r.window.Min.X, r.window.Min.Y, w, h, r.data)
+		}
+		if err == nil {
+			written++
+			progress := options.Progress
+			if progress != nil && progress(float64(written)/float64(len(windows)), "", options.ProgressData) == 0 {
+				err = &Error{Class: CE_Failure, Num: CPLE_UserInterrupt, Msg: "User terminated"}
+			}
+		}
+		if err != nil {
+			cancel()
+		}
+	}
+	if err == nil && written < len(windows) {
+		err = ctx.Err()
+	}
+	// Keep the dataset of the output band from being finalized while
+	// blocks are written
+	runtime.KeepAlive(output.parent)
+	return err
+}
+
+// Open the input bands for one worker, returning a function closing the
+// datasets opened
+func openInputs(inputs []BandInput, xSize, ySize int) ([]RasterBand, func(), error) {
+	datasets := make(map[string]Dataset)
+	closeInputs := func() {
+		for _, dataset := range datasets {
+			dataset.Close()
+		}
+	}
+
+	bands := make([]RasterBand, len(inputs))
+	for i, input := range inputs {
+		dataset, ok := datasets[input.Filename]
+		if !ok {
+			var err error
+			dataset, err = Open(input.Filename, ReadOnly)
+			if err != nil {
+				closeInputs()
+				return nil, nil, err
+			}
+			datasets[input.Filename] = dataset
+		}
+		if input.Band < 1 || input.Band > dataset.RasterCount() {
+			closeInputs()
+			return nil, nil, fmt.Errorf("Error: band %d of %s is out of range", input.Band, input.Filename)
+		}
+		band := dataset.RasterBand(input.Band)
+		if band.XSize() != xSize || band.YSize() != ySize {
+			closeInputs()
+			return nil, nil, fmt.Errorf(
+				"Error: band %d of %s is %dx%d, output is %dx%d",
+				input.Band, input.Filename, band.XSize(), band.YSize(), xSize, ySize,
+			)
+		}
+		bands[i] = band
+	}
+	return bands, closeInputs, nil
+}
@@ -0,0 +1,170 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  CFLAGS: -I/usr/include/gdal
+#cgo linux  LDFLAGS: -lgdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Typed raster IO.                                                */
+/* -------------------------------------------------------------------- */
+
+// Complex pixel with 16 bit integer parts, as stored for CInt16 bands
+type ComplexInt16 struct {
+	Real, Imag int16
+}
+
+// Complex pixel with 32 bit integer parts, as stored for CInt32 bands
+type ComplexInt32 struct {
+	Real, Imag int32
+}
+
+// Pixel is the set of Go types that map to a GDAL data type
+type Pixel interface {
+	uint8 | uint16 | int16 | uint32 | int32 | float32 | float64 |
+		ComplexInt16 | ComplexInt32 | complex64 | complex128
+}
+
+// Return the GDAL data type matching the pixel type T
+func DataTypeOf[T Pixel]() DataType {
+	var pixel T
+	switch any(pixel).(type) {
+	case uint8:
+		return Byte
+	case uint16:
+		return UInt16
+	case int16:
+		return Int16
+	case uint32:
+		return UInt32
+	case int32:
+		return Int32
+	case float32:
+		return Float32
+	case float64:
+		return Float64
+	case ComplexInt16:
+		return CInt16
+	case ComplexInt32:
+		return CInt32
+	case complex64:
+		return CFloat32
+	case complex128:
+		return CFloat64
+	}
+	return Unknown
+}
+
+// Return the address of the first element of a slice, or nil if it is empty
+func slicePointer[T Pixel](data []T) unsafe.Pointer {
+	if len(data) == 0 {
+		return nil
+	}
+	return unsafe.Pointer(&data[0])
+}
+
+// Compute the number of bytes spanned by a bufXSize by bufYSize buffer of
+// pixels of the given size, defaulting pixelSpace and lineSpace when zero
+func bufferExtent(bufXSize, bufYSize, pixelSize, pixelSpace, lineSpace int) (int, int, int, error) {
+	if bufXSize < 0 || bufYSize < 0 {
+		return 0, 0, 0, fmt.Errorf("Error: invalid buffer size %dx%d", bufXSize, bufYSize)
+	}
+	if pixelSpace < 0 || lineSpace < 0 {
+		return 0, 0, 0, fmt.Errorf("Error: negative pixel (%d) or line (%d) spacing is not supported", pixelSpace, lineSpace)
+	}
+	if pixelSpace == 0 {
+		pixelSpace = pixelSize
+	}
+	if lineSpace == 0 {
+		lineSpace = pixelSpace * bufXSize
+	}
+	if bufXSize == 0 || bufYSize == 0 {
+		return 0, pixelSpace, lineSpace, nil
+	}
+	extent := (bufYSize-1)*lineSpace + (bufXSize-1)*pixelSpace + pixelSize
+	return extent, pixelSpace, lineSpace, nil
+}
+
+// Read or write a window of the band through a typed buffer.  The window
+// at xOff, yOff of xSize by ySize pixels is resampled to bufXSize by
+// bufYSize pixels; pixelSpace and lineSpace give the byte offset between
+// pixels and lines in buffer, or 0 for a packed buffer.  An error is
+// returned if buffer is too small to hold the requested pixels.
+func RasterIO[T Pixel](
+	band RasterBand,
+	rwFlag RWFlag,
+	xOff, yOff, xSize, ySize int,
+	buffer []T,
+	bufXSize, bufYSize int,
+	pixelSpace, lineSpace int,
+) error {
+	band.check()
+	var pixel T
+	dataType := DataTypeOf[T]()
+	pixelSize := int(unsafe.Sizeof(pixel))
+
+	err := validateWindow(xOff, yOff, xSize, ySize, band.XSize(), band.YSize())
+	if err != nil {
+		return err
+	}
+	extent, pixelSpace, lineSpace, err := bufferExtent(bufXSize, bufYSize, pixelSize, pixelSpace, lineSpace)
+	if err != nil {
+		return err
+	}
+	if extent > len(buffer)*pixelSize {
+		return fmt.Errorf(
+			"Error: buffer of %d %s pixels is too small for a %dx%d window (needs %d bytes, has %d)",
+			len(buffer), dataType.Name(), bufXSize, bufYSize, extent, len(buffer)*pixelSize,
+		)
+	}
+	if extent == 0 {
+		return nil
+	}
+
+	err = cplCall(func() C.CPLErr {
+		return C.GDALRasterIO(
+			band.cval,
+			C.GDALRWFlag(rwFlag),
+			C.int(xOff), C.int(yOff), C.int(xSize), C.int(ySize),
+			slicePointer(buffer),
+			C.int(bufXSize), C.int(bufYSize),
+			C.GDALDataType(dataType),
+			C.int(pixelSpace), C.int(lineSpace),
+		)
+	})
+	// Keep the dataset of the band from being finalized during the call
+	runtime.KeepAlive(band.parent)
+	return err
+}
+
+// Read a window of the band into a newly allocated slice, converting
+// pixels to T
+func ReadWindow[T Pixel](band RasterBand, xOff, yOff, xSize, ySize int) ([]T, error) {
+	if xSize < 0 || ySize < 0 {
+		return nil, fmt.Errorf("Error: invalid window size %dx%d", xSize, ySize)
+	}
+	buffer := make([]T, xSize*ySize)
+	err := RasterIO(band, Read, xOff, yOff, xSize, ySize, buffer, xSize, ySize, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return buffer, nil
+}
+
+// Write a packed buffer of xSize by ySize pixels to a window of the band,
+// converting pixels from T to the band data type
+func WriteWindow[T Pixel](band RasterBand, xOff, yOff, xSize, ySize int, buffer []T) error {
+	return RasterIO(band, Write, xOff, yOff, xSize, ySize, buffer, xSize, ySize, 0, 0)
+}
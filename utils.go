@@ -0,0 +1,988 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  CFLAGS: -I/usr/include/gdal
+#cgo linux  LDFLAGS: -lgdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"math"
+	"runtime"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Helpers shared by the GDAL utility wrappers.                    */
+/* -------------------------------------------------------------------- */
+
+// Floating point value reported by the utilities as a JSON number, or as
+// a string for NaN and infinities
+type InfoFloat float64
+
+func (f *InfoFloat) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) != nil {
+		return json.Unmarshal(data, (*float64)(f))
+	}
+	switch strings.ToLower(s) {
+	case "nan":
+		*f = InfoFloat(math.NaN())
+	case "inf", "infinity":
+		*f = InfoFloat(math.Inf(1))
+	case "-inf", "-infinity":
+		*f = InfoFloat(math.Inf(-1))
+	default:
+		return json.Unmarshal([]byte(s), (*float64)(f))
+	}
+	return nil
+}
+
+func (f InfoFloat) MarshalJSON() ([]byte, error) {
+	switch v := float64(f); {
+	case math.IsNaN(v):
+		return []byte(`"nan"`), nil
+	case math.IsInf(v, 1):
+		return []byte(`"inf"`), nil
+	case math.IsInf(v, -1):
+		return []byte(`"-inf"`), nil
+	}
+	return json.Marshal(float64(f))
+}
+
+// Metadata items by domain.  Domains holding a list of strings, such as
+// xml:XMP, are reported as a single item with an empty name holding the
+// lines of the list.
+type InfoMetadata map[string]map[string]string
+
+func (m *InfoMetadata) UnmarshalJSON(data []byte) error {
+	var domains map[string]json.RawMessage
+	if err := json.Unmarshal(data, &domains); err != nil {
+		return err
+	}
+	*m = make(InfoMetadata, len(domains))
+	for domain, raw := range domains {
+		var items map[string]string
+		if json.Unmarshal(raw, &items) == nil {
+			(*m)[domain] = items
+			continue
+		}
+		var lines []string
+		if err := json.Unmarshal(raw, &lines); err != nil {
+			return err
+		}
+		(*m)[domain] = map[string]string{"": strings.Join(lines, "\n")}
+	}
+	return nil
+}
+
+// Decode and free a JSON report returned by a utility
+func decodeReport(report *C.char, v interface{}) error {
+	defer C.VSIFree(unsafe.Pointer(report))
+	return json.Unmarshal([]byte(C.GoString(report)), v)
+}
+
+/* -------------------------------------------------------------------- */
+/*      gdalinfo                                                        */
+/* -------------------------------------------------------------------- */
+
+// Options for Dataset.Info, matching gdalinfo switches
+type InfoOptions struct {
+	// Compute statistics (-stats), or approximate ones (-approx_stats)
+	Stats       bool
+	ApproxStats bool
+	// Report histograms (-hist)
+	Histogram bool
+	// Report band checksums (-checksum)
+	Checksum bool
+	// Report the metadata domains available (-listmdd) and the items of
+	// further domains (-mdd), or of all domains with "all"
+	ListMetadataDomains bool
+	MetadataDomains     []string
+	// Omit GCPs (-nogcp), metadata (-nomd), color tables (-noct) and the
+	// file list (-nofl)
+	NoGCP        bool
+	NoMetadata   bool
+	NoColorTable bool
+	NoFileList   bool
+	// Further gdalinfo switches
+	ExtraArgs []string
+}
+
+func (options InfoOptions) args() []string {
+	args := []string{"-json"}
+	flags := []struct {
+		set  bool
+		flag string
+	}{
+		{options.Stats, "-stats"},
+		{options.ApproxStats, "-approx_stats"},
+		{options.Histogram, "-hist"},
+		{options.Checksum, "-checksum"},
+		{options.ListMetadataDomains, "-listmdd"},
+		{options.NoGCP, "-nogcp"},
+		{options.NoMetadata, "-nomd"},
+		{options.NoColorTable, "-noct"},
+		{options.NoFileList, "-nofl"},
+	}
+	for _, f := range flags {
+		if f.set {
+			args = append(args, f.flag)
+		}
+	}
+	for _, domain := range options.MetadataDomains {
+		args = append(args, "-mdd", domain)
+	}
+	return append(args, options.ExtraArgs...)
+}
+
+// Report on a dataset, as produced by gdalinfo -json
+type DatasetInfo struct {
+	Description      string             `json:"description"`
+	DriverShortName  string             `json:"driverShortName"`
+	DriverLongName   string             `json:"driverLongName"`
+	Files            []string           `json:"files,omitempty"`
+	Size             [2]int             `json:"size"`
+	CoordinateSystem *InfoSRS           `json:"coordinateSystem,omitempty"`
+	GeoTransform     []float64          `json:"geoTransform,omitempty"`
+	GCPs             *InfoGCPs          `json:"gcps,omitempty"`
+	Metadata         InfoMetadata       `json:"metadata,omitempty"`
+	Corners          *CornerCoordinates `json:"cornerCoordinates,omitempty"`
+	WGS84Extent      *InfoPolygon       `json:"wgs84Extent,omitempty"`
+	Bands            []BandInfo         `json:"bands"`
+}
+
+// Spatial reference system in a report
+type InfoSRS struct {
+	WKT                      string `json:"wkt"`
+	DataAxisToSRSAxisMapping []int  `json:"dataAxisToSRSAxisMapping,omitempty"`
+}
+
+// Ground control points in a report
+type InfoGCPs struct {
+	CoordinateSystem *InfoSRS `json:"coordinateSystem,omitempty"`
+	GCPList          []GCP    `json:"gcpList"`
+}
+
+// Georeferenced corners and center of a raster, in the dataset
+// coordinate system
+type CornerCoordinates struct {
+	UpperLeft  []float64 `json:"upperLeft"`
+	LowerLeft  []float64 `json:"lowerLeft"`
+	LowerRight []float64 `json:"lowerRight"`
+	UpperRight []float64 `json:"upperRight"`
+	Center     []float64 `json:"center"`
+}
+
+// GeoJSON polygon in a report
+type InfoPolygon struct {
+	Type        string        `json:"type"`
+	Coordinates [][][]float64 `json:"coordinates"`
+}
+
+// Report on a raster band
+type BandInfo struct {
+	Band                int             `json:"band"`
+	Block               [2]int          `json:"block"`
+	Type                string          `json:"type"`
+	ColorInterpretation string          `json:"colorInterpretation"`
+	Description         string          `json:"description,omitempty"`
+	Min                 *float64        `json:"min,omitempty"`
+	Max                 *float64        `json:"max,omitempty"`
+	Minimum             *float64        `json:"minimum,omitempty"`
+	Maximum             *float64        `json:"maximum,omitempty"`
+	Mean                *float64        `json:"mean,omitempty"`
+	StdDev              *float64        `json:"stdDev,omitempty"`
+	NoDataValue         *InfoFloat      `json:"noDataValue,omitempty"`
+	Offset              *float64        `json:"offset,omitempty"`
+	Scale               *float64        `json:"scale,omitempty"`
+	Unit                string          `json:"unit,omitempty"`
+	Checksum            *int            `json:"checksum,omitempty"`
+	Histogram           *InfoHistogram  `json:"histogram,omitempty"`
+	Overviews           []OverviewInfo  `json:"overviews,omitempty"`
+	Mask                *MaskInfo       `json:"mask,omitempty"`
+	ColorTable          *InfoColorTable `json:"colorTable,omitempty"`
+	Metadata            InfoMetadata    `json:"metadata,omitempty"`
+}
+
+// Histogram in a band report
+type InfoHistogram struct {
+	Count   int     `json:"count"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Buckets []int   `json:"buckets"`
+}
+
+// Overview in a band report
+type OverviewInfo struct {
+	Size     [2]int `json:"size"`
+	Checksum *int   `json:"checksum,omitempty"`
+}
+
+// Mask band in a band report
+type MaskInfo struct {
+	Flags     []string       `json:"flags"`
+	Overviews []OverviewInfo `json:"overviews,omitempty"`
+}
+
+// Color table in a band report, with one [4]int entry per color
+type InfoColorTable struct {
+	Palette string   `json:"palette"`
+	Count   int      `json:"count"`
+	Entries [][4]int `json:"entries"`
+}
+
+// Report on the dataset, equivalent to gdalinfo -json
+func (dataset Dataset) Info(options InfoOptions) (*DatasetInfo, error) {
+	dataset.check()
+	argv, free := cStringList(options.args())
+	defer free()
+
+	var report *C.char
+	messages := captureErrors(func() {
+		opts := C.GDALInfoOptionsNew(argv, nil)
+		if opts == nil {
+			return
+		}
+		defer C.GDALInfoOptionsFree(opts)
+		report = C.GDALInfo(dataset.cval, opts)
+	})
+	if report == nil {
+		return nil, failure(messages, "Error: gdalinfo failed")
+	}
+
+	info := new(DatasetInfo)
+	if err := decodeReport(report, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+/* -------------------------------------------------------------------- */
+/*      ogrinfo                                                         */
+/* -------------------------------------------------------------------- */
+
+// Options for DataSource.Info
+type VectorInfoOptions struct {
+	// Names of the layers to report on, or all layers if empty
+	Layers []string
+	// Report counts and extents only when cheap to compute, rather than
+	// scanning the layers when needed
+	NoForce bool
+	// Number of features reported per layer as samples
+	Features int
+}
+
+// Report on a data source, following the summary output of
+// ogrinfo -json -so
+type DataSourceInfo struct {
+	Description     string      `json:"description"`
+	DriverShortName string      `json:"driverShortName"`
+	Layers          []LayerInfo `json:"layers"`
+}
+
+// Report on a layer.  FeatureCount is -1 when unknown.
+type LayerInfo struct {
+	Name           string              `json:"name"`
+	FeatureCount   int                 `json:"featureCount"`
+	FIDColumnName  string              `json:"fidColumnName,omitempty"`
+	GeometryFields []GeometryFieldInfo `json:"geometryFields"`
+	Fields         []FieldInfo         `json:"fields"`
+	Features       []FeatureInfo       `json:"features,omitempty"`
+}
+
+// Geometry field in a layer report.  Extent holds the minimum x, minimum
+// y, maximum x and maximum y, and is empty when unknown.
+type GeometryFieldInfo struct {
+	Name             string    `json:"name"`
+	Type             string    `json:"type"`
+	Nullable         bool      `json:"nullable"`
+	Extent           []float64 `json:"extent,omitempty"`
+	CoordinateSystem *InfoSRS  `json:"coordinateSystem,omitempty"`
+	EPSG             int       `json:"epsg,omitempty"`
+}
+
+// Attribute field in a layer report
+type FieldInfo struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Width     int    `json:"width,omitempty"`
+	Precision int    `json:"precision,omitempty"`
+	Nullable  bool   `json:"nullable"`
+}
+
+// Sample feature in a layer report, with the fields that are set and the
+// geometry of the first geometry field as WKT
+type FeatureInfo struct {
+	FID        int               `json:"fid"`
+	Properties map[string]string `json:"properties"`
+	Geometry   string            `json:"geometry,omitempty"`
+}
+
+// Report on the data source and its layers, equivalent to
+// ogrinfo -json -so
+func (ds DataSource) Info(options VectorInfoOptions) (*DataSourceInfo, error) {
+	ds.check()
+	info := &DataSourceInfo{
+		Description:     ds.Name(),
+		DriverShortName: ds.Driver().Name(),
+		Layers:          []LayerInfo{},
+	}
+
+	var layers []Layer
+	if len(options.Layers) == 0 {
+		for i := 0; i < ds.LayerCount(); i++ {
+			layers = append(layers, ds.LayerByIndex(i))
+		}
+	}
+	for _, name := range options.Layers {
+		layer := ds.LayerByName(name)
+		if layer.cval == nil {
+			return nil, fmt.Errorf("Error: layer %s not found", name)
+		}
+		layers = append(layers, layer)
+	}
+
+	for _, layer := range layers {
+		layerInfo, err := layer.info(options)
+		if err != nil {
+			return nil, err
+		}
+		info.Layers = append(info.Layers, layerInfo)
+	}
+	return info, nil
+}
+
+// Report on a single layer
+func (layer Layer) info(options VectorInfoOptions) (LayerInfo, error) {
+	layer.check()
+	force := !options.NoForce
+	count, _ := layer.FeatureCount(force)
+	info := LayerInfo{
+		Name:           layer.Name(),
+		FeatureCount:   count,
+		FIDColumnName:  layer.FIDColumn(),
+		GeometryFields: []GeometryFieldInfo{},
+		Fields:         []FieldInfo{},
+	}
+
+	defn := layer.Definition()
+	for i := 0; i < int(C.OGR_FD_GetGeomFieldCount(defn.cval)); i++ {
+		field := C.OGR_FD_GetGeomFieldDefn(defn.cval, C.int(i))
+		geomField := GeometryFieldInfo{
+			Name:     C.GoString(C.OGR_GFld_GetNameRef(field)),
+			Type:     GeometryType(C.OGR_GFld_GetType(field)).Name(),
+			Nullable: C.OGR_GFld_IsNullable(field) != 0,
+		}
+
+		var env C.OGREnvelope
+		if C.OGR_L_GetExtentEx(layer.cval, C.int(i), &env, BoolToCInt(force)) == C.OGRERR_NONE {
+			geomField.Extent = []float64{
+				float64(env.MinX), float64(env.MinY), float64(env.MaxX), float64(env.MaxY),
+			}
+		}
+
+		if sr := (SpatialReference{cval: C.OGR_GFld_GetSpatialRef(field)}); sr.cval != nil {
+			wkt, err := sr.ToWKT()
+			if err != nil {
+				return LayerInfo{}, err
+			}
+			geomField.CoordinateSystem = &InfoSRS{WKT: wkt}
+			if C.GoString(C.OSRGetAuthorityName(sr.cval, nil)) == "EPSG" {
+				geomField.EPSG, _ = strconv.Atoi(C.GoString(C.OSRGetAuthorityCode(sr.cval, nil)))
+			}
+		}
+		info.GeometryFields = append(info.GeometryFields, geomField)
+	}
+
+	for i := 0; i < defn.FieldCount(); i++ {
+		field := defn.FieldDefinition(i)
+		info.Fields = append(info.Fields, FieldInfo{
+			Name:      field.Name(),
+			Type:      field.Type().Name(),
+			Width:     field.Width(),
+			Precision: field.Precision(),
+			Nullable:  field.IsNullable(),
+		})
+	}
+
+	if options.Features > 0 {
+		layer.ResetReading()
+		defer layer.ResetReading()
+		for len(info.Features) < options.Features {
+			feature, ok := layer.NextFeature()
+			if !ok {
+				break
+			}
+			info.Features = append(info.Features, featureInfo(feature, defn))
+			feature.Close()
+		}
+	}
+	return info, nil
+}
+
+// Report on a sample feature
+func featureInfo(feature Feature, defn FeatureDefinition) FeatureInfo {
+	info := FeatureInfo{
+		FID:        feature.FID(),
+		Properties: make(map[string]string),
+	}
+	for i := 0; i < defn.FieldCount(); i++ {
+		if feature.IsFieldSet(i) {
+			info.Properties[defn.FieldDefinition(i).Name()] = feature.FieldAsString(i)
+		}
+	}
+	if geom, ok := feature.Geometry(); ok {
+		info.Geometry, _ = geom.ToWKT()
+	}
+	return info
+}
+
+/* -------------------------------------------------------------------- */
+/*      gdal_translate                                                  */
+/* -------------------------------------------------------------------- */
+
+// Linear rescaling of pixel values for Translate.  A source range with
+// SrcMin equal to SrcMax is computed from the data, and a destination range
+// with DstMin equal to DstMax is the range of the output data type.  A
+// destination range needs a source range.
+type TranslateScale struct {
+	SrcMin, SrcMax float64
+	DstMin, DstMax float64
+}
+
+// Options for Translate, matching gdal_translate switches
+type TranslateOptions struct {
+	// Output driver short name (-of) and creation options (-co)
+	Format          string
+	CreationOptions []string
+	// Output data type (-ot), or Unknown for the source data type
+	OutputType DataType
+	// Rescale pixel values (-scale), raising them to Exponent (-exponent)
+	// if non-zero; Exponent needs Scale
+	Scale    *TranslateScale
+	Exponent float64
+	// Source bands to copy, in output order (-b), or nil for all bands
+	Bands []int
+	// Source window in pixels (-srcwin), or georeferenced as upper left x,
+	// upper left y, lower right x and lower right y (-projwin), optionally
+	// in another SRS than the source (-projwin_srs)
+	SrcWindow  *image.Rectangle
+	ProjWindow *[4]float64
+	ProjWinSRS string
+	// Output size in pixels (-outsize) or resolution in georeferenced
+	// units (-tr); zero values are left to GDAL
+	Width, Height int
+	XRes, YRes    float64
+	// Nodata value assigned to the output bands (-a_nodata)
+	NoData *float64
+	// SRS (-a_srs) and upper left x, upper left y, lower right x and lower
+	// right y bounds (-a_ullr) assigned to the output
+	AssignSRS    string
+	AssignBounds *[4]float64
+	// Do not copy dataset and band metadata to the output
+	StripMetadata bool
+	// Further gdal_translate switches
+	ExtraArgs []string
+	// Progress callback
+	Progress     ProgressFunc
+	ProgressData interface{}
+}
+
+// Format a float for a utility command line
+func formatArg(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// Switches selecting the output format
+func (options TranslateOptions) outputArgs() []string {
+	var args []string
+	if options.Format != "" {
+		args = append(args, "-of", options.Format)
+	}
+	for _, option := range options.CreationOptions {
+		args = append(args, "-co", option)
+	}
+	return args
+}
+
+// Switches converting the source, other than the output format
+func (options TranslateOptions) args() ([]string, error) {
+	args := []string{}
+	if options.OutputType != Unknown {
+		args = append(args, "-ot", options.OutputType.Name())
+	}
+	if scale := options.Scale; scale != nil {
+		args = append(args, "-scale")
+		if scale.SrcMin != scale.SrcMax {
+			args = append(args, formatArg(scale.SrcMin), formatArg(scale.SrcMax))
+			if scale.DstMin != scale.DstMax {
+				args = append(args, formatArg(scale.DstMin), formatArg(scale.DstMax))
+			}
+		} else if scale.DstMin != scale.DstMax {
+			return nil, fmt.Errorf("Error: a destination scaling range needs a source range")
+		}
+		if options.Exponent != 0 {
+			args = append(args, "-exponent", formatArg(options.Exponent))
+		}
+	} else if options.Exponent != 0 {
+		return nil, fmt.Errorf("Error: Exponent needs Scale")
+	}
+	for _, band := range options.Bands {
+		args = append(args, "-b", strconv.Itoa(band))
+	}
+	if window := options.SrcWindow; window != nil {
+		args = append(args, "-srcwin",
+			strconv.Itoa(window.Min.X), strconv.Itoa(window.Min.Y),
+			strconv.Itoa(window.Dx()), strconv.Itoa(window.Dy()),
+		)
+	}
+	if window := options.ProjWindow; window != nil {
+		args = append(args, "-projwin")
+		for _, v := range window {
+			args = append(args, formatArg(v))
+		}
+		if options.ProjWinSRS != "" {
+			args = append(args, "-projwin_srs", options.ProjWinSRS)
+		}
+	}
+	if options.Width != 0 || options.Height != 0 {
+		args = append(args, "-outsize", strconv.Itoa(options.Width), strconv.Itoa(options.Height))
+	}
+	if options.XRes != 0 || options.YRes != 0 {
+		args = append(args, "-tr", formatArg(options.XRes), formatArg(options.YRes))
+	}
+	if options.NoData != nil {
+		args = append(args, "-a_nodata", formatArg(*options.NoData))
+	}
+	if options.AssignSRS != "" {
+		args = append(args, "-a_srs", options.AssignSRS)
+	}
+	if bounds := options.AssignBounds; bounds != nil {
+		args = append(args, "-a_ullr")
+		for _, v := range bounds {
+			args = append(args, formatArg(v))
+		}
+	}
+	return append(args, options.ExtraArgs...), nil
+}
+
+// Convert a raster dataset to the dst file, equivalent to gdal_translate.
+// The returned dataset is open on the output, and must be closed to flush
+// it to disk.
+func Translate(dst string, src Dataset, options TranslateOptions) (Dataset, error) {
+	src.check()
+	if options.StripMetadata && strings.EqualFold(options.Format, "VRT") {
+		return Dataset{}, fmt.Errorf("Error: metadata cannot be stripped from VRT output")
+	}
+	args, err := options.args()
+	if err != nil {
+		return Dataset{}, err
+	}
+	if !options.StripMetadata {
+		args = append(args, options.outputArgs()...)
+		return translate(dst, src, args, options.Progress, options.ProgressData)
+	}
+
+	// gdal_translate always copies metadata, so convert through a virtual
+	// dataset cleared of it
+	vrt, err := translate("", src, append(args, "-of", "VRT"), nil, nil)
+	if err != nil {
+		return Dataset{}, err
+	}
+	defer vrt.Close()
+	objects := []C.GDALMajorObjectH{C.GDALMajorObjectH(vrt.cval)}
+	for i := 1; i <= vrt.RasterCount(); i++ {
+		objects = append(objects, C.GDALMajorObjectH(vrt.RasterBand(i).cval))
+	}
+	for _, object := range objects {
+		err := cplCall(func() C.CPLErr {
+			return C.GDALSetMetadata(object, nil, nil)
+		})
+		if err != nil {
+			return Dataset{}, err
+		}
+	}
+	return translate(dst, vrt, options.outputArgs(), options.Progress, options.ProgressData)
+}
+
+// Run GDALTranslate with the given switches
+func translate(dst string, src Dataset, args []string, progress ProgressFunc, data interface{}) (Dataset, error) {
+	cDst := C.CString(dst)
+	defer C.free(unsafe.Pointer(cDst))
+	argv, free := cStringList(args)
+	defer free()
+	pf, pa, release := progressProxy(progress, data)
+	defer release()
+
+	var dataset C.GDALDatasetH
+	messages := captureErrors(func() {
+		opts := C.GDALTranslateOptionsNew(argv, nil)
+		if opts == nil {
+			return
+		}
+		defer C.GDALTranslateOptionsFree(opts)
+		C.GDALTranslateOptionsSetProgress(opts, pf, pa)
+		dataset = C.GDALTranslate(cDst, src.cval, opts, nil)
+	})
+	// Keep the source from being finalized, closing it, during the call
+	runtime.KeepAlive(src.own)
+	if dataset == nil {
+		return Dataset{}, failure(messages, "Error: gdal_translate of '%s' failed", dst)
+	}
+	return ownDataset(dataset), nil
+}
+
+/* -------------------------------------------------------------------- */
+/*      gdalwarp                                                        */
+/* -------------------------------------------------------------------- */
+
+// Options for Warp, matching gdalwarp switches
+type WarpOpts struct {
+	// Output driver short name (-of) and creation options (-co)
+	Format          string
+	CreationOptions []string
+	// Output data type (-ot), or Unknown for the source data type
+	OutputType DataType
+	// Source SRS overriding that of the sources (-s_srs), and target SRS
+	// (-t_srs), or empty for the source SRS
+	SrcSRS, DstSRS string
+	// Output resolution in target georeferenced units (-tr), with both or
+	// neither set
+	XRes, YRes float64
+	// Output extent as minimum x, minimum y, maximum x and maximum y
+	// (-te), in ExtentSRS (-te_srs) if set, otherwise in the target SRS
+	Extent    *[4]float64
+	ExtentSRS string
+	// Output size in pixels (-ts); zero values are computed
+	Width, Height int
+	// Resampling algorithm (-r)
+	Resampling ResampleAlg
+	// Nodata values of the source bands (-srcnodata) and assigned to the
+	// output bands (-dstnodata), one for all bands or one per band
+	SrcNoData, DstNoData []float64
+	// Geometry, in the SRS it is assigned or the target SRS otherwise,
+	// outside of which output pixels are left blank (-cutline), and whether
+	// to crop the output extent to it (-crop_to_cutline)
+	Cutline       Geometry
+	CropToCutline bool
+	// Overlap I/O and warping (-multi), and the number of threads warping
+	// (-wo NUM_THREADS), or 0 for all CPUs with Multithread and a single
+	// thread otherwise
+	Multithread bool
+	Threads     int
+	// Working memory in megabytes (-wm), or 0 for the default
+	MemoryLimit int
+	// Error threshold in pixels for the approximated transformation
+	// (-et), or nil for the default; 0 uses the exact transformation
+	ErrorThreshold *float64
+	// Further gdalwarp switches
+	ExtraArgs []string
+	// Progress callback
+	Progress     ProgressFunc
+	ProgressData interface{}
+}
+
+// Format a list of floats as a single utility argument
+func formatArgs(values []float64) string {
+	args := make([]string, len(values))
+	for i, v := range values {
+		args[i] = formatArg(v)
+	}
+	return strings.Join(args, " ")
+}
+
+func (options WarpOpts) args() ([]string, error) {
+	args := []string{}
+	if options.Format != "" {
+		args = append(args, "-of", options.Format)
+	}
+	for _, option := range options.CreationOptions {
+		args = append(args, "-co", option)
+	}
+	if options.OutputType != Unknown {
+		args = append(args, "-ot", options.OutputType.Name())
+	}
+	if options.SrcSRS != "" {
+		args = append(args, "-s_srs", options.SrcSRS)
+	}
+	if options.DstSRS != "" {
+		args = append(args, "-t_srs", options.DstSRS)
+	}
+	if (options.XRes != 0) != (options.YRes != 0) {
+		return nil, fmt.Errorf("Error: XRes and YRes must be set together")
+	}
+	if options.XRes != 0 {
+		args = append(args, "-tr", formatArg(options.XRes), formatArg(options.YRes))
+	}
+	if extent := options.Extent; extent != nil {
+		args = append(args, "-te")
+		for _, v := range extent {
+			args = append(args, formatArg(v))
+		}
+		if options.ExtentSRS != "" {
+			args = append(args, "-te_srs", options.ExtentSRS)
+		}
+	}
+	if options.Width != 0 || options.Height != 0 {
+		args = append(args, "-ts", strconv.Itoa(options.Width), strconv.Itoa(options.Height))
+	}
+	args = append(args, "-r", options.Resampling.String())
+	if len(options.SrcNoData) > 0 {
+		args = append(args, "-srcnodata", formatArgs(options.SrcNoData))
+	}
+	if len(options.DstNoData) > 0 {
+		args = append(args, "-dstnodata", formatArgs(options.DstNoData))
+	}
+	if cutline := options.Cutline; cutline.cval != nil {
+		wkt, err := cutline.ToWKT()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "-cutline", wkt)
+		if sr := cutline.SpatialReference(); sr.cval != nil {
+			srs, err := sr.ToWKT()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, "-cutline_srs", srs)
+		}
+		if options.CropToCutline {
+			args = append(args, "-crop_to_cutline")
+		}
+	}
+	if options.Multithread {
+		args = append(args, "-multi")
+	}
+	if options.Threads > 0 {
+		args = append(args, "-wo", "NUM_THREADS="+strconv.Itoa(options.Threads))
+	} else if options.Multithread {
+		args = append(args, "-wo", "NUM_THREADS=ALL_CPUS")
+	}
+	if options.MemoryLimit > 0 {
+		// Values of 10000 and more are read as bytes rather than megabytes
+		args = append(args, "-wm", strconv.Itoa(options.MemoryLimit*1024*1024))
+	}
+	if options.ErrorThreshold != nil {
+		args = append(args, "-et", formatArg(*options.ErrorThreshold))
+	}
+	return append(args, options.ExtraArgs...), nil
+}
+
+// Reproject and mosaic the source datasets into the dst file, equivalent
+// to gdalwarp.  Unless set by the options, the output grid is computed to
+// cover all sources at about their resolution.  The returned dataset is
+// open on the output, and must be closed to flush it to disk.
+//
+// Cutlines given as WKT require GDAL 3.7 or later.
+func Warp(dst string, srcs []Dataset, options WarpOpts) (Dataset, error) {
+	if len(srcs) == 0 {
+		return Dataset{}, fmt.Errorf("Error: no source datasets to warp")
+	}
+	cSrcs := make([]C.GDALDatasetH, len(srcs))
+	for i, src := range srcs {
+		src.check()
+		cSrcs[i] = src.cval
+	}
+	args, err := options.args()
+	if err != nil {
+		return Dataset{}, err
+	}
+
+	cDst := C.CString(dst)
+	defer C.free(unsafe.Pointer(cDst))
+	argv, free := cStringList(args)
+	defer free()
+	pf, pa, release := progressProxy(options.Progress, options.ProgressData)
+	defer release()
+
+	var dataset C.GDALDatasetH
+	messages := captureErrors(func() {
+		opts := C.GDALWarpAppOptionsNew(argv, nil)
+		if opts == nil {
+			return
+		}
+		defer C.GDALWarpAppOptionsFree(opts)
+		C.GDALWarpAppOptionsSetProgress(opts, pf, pa)
+		dataset = C.GDALWarp(cDst, nil, C.int(len(cSrcs)), &cSrcs[0], opts, nil)
+	})
+	// Keep the sources from being finalized, closing them, during the call
+	runtime.KeepAlive(srcs)
+	if dataset == nil {
+		return Dataset{}, failure(messages, "Error: gdalwarp to '%s' failed", dst)
+	}
+	return ownDataset(dataset), nil
+}
+
+/* -------------------------------------------------------------------- */
+/*      ogr2ogr                                                         */
+/* -------------------------------------------------------------------- */
+
+// How VectorTranslate writes to the destination
+type VectorTranslateMode int
+
+const (
+	// Create a new data source
+	VTM_Create = VectorTranslateMode(iota)
+	// Open an existing data source and add new layers to it (-update)
+	VTM_Update
+	// Append features to existing layers (-append)
+	VTM_Append
+	// Replace existing layers (-overwrite)
+	VTM_Overwrite
+)
+
+// Options for VectorTranslate, matching ogr2ogr switches
+type VectorTranslateOptions struct {
+	// Output driver short name (-f), data source creation options (-dsco)
+	// and layer creation options (-lco)
+	Format                 string
+	DatasetCreationOptions []string
+	LayerCreationOptions   []string
+	// Writing mode
+	Mode VectorTranslateMode
+	// Source layers to copy, or nil for all layers
+	Layers []string
+	// Name of the output layer (-nln)
+	LayerName string
+	// SQL statement selecting the features to copy (-sql), in the given
+	// dialect (-dialect), or attribute filter (-where)
+	SQL        string
+	SQLDialect string
+	Where      string
+	// Spatial filter as minimum x, minimum y, maximum x and maximum y
+	// (-spat), in SpatialFilterSRS (-spat_srs) if set, otherwise in the
+	// layer SRS
+	SpatialFilter    *[4]float64
+	SpatialFilterSRS string
+	// Source SRS overriding that of the layers (-s_srs), and target SRS
+	// to reproject to (-t_srs)
+	SrcSRS, DstSRS string
+	// Output geometry type (-nlt), such as "MULTIPOLYGON" or
+	// "PROMOTE_TO_MULTI"
+	GeometryType string
+	// Fields to copy (-select), or nil for all fields
+	Fields []string
+	// Index of the output field receiving each source field, or -1 to
+	// skip it, when appending (-fieldmap)
+	FieldMap []int
+	// Number of features written per transaction (-gt), 0 for the
+	// default, or -1 for a single transaction
+	GroupTransactions int
+	// Further ogr2ogr switches
+	ExtraArgs []string
+	// Progress callback
+	Progress     ProgressFunc
+	ProgressData interface{}
+}
+
+func (options VectorTranslateOptions) args() []string {
+	args := []string{}
+	if options.Format != "" {
+		args = append(args, "-f", options.Format)
+	}
+	for _, option := range options.DatasetCreationOptions {
+		args = append(args, "-dsco", option)
+	}
+	for _, option := range options.LayerCreationOptions {
+		args = append(args, "-lco", option)
+	}
+	switch options.Mode {
+	case VTM_Update:
+		args = append(args, "-update")
+	case VTM_Append:
+		args = append(args, "-append")
+	case VTM_Overwrite:
+		args = append(args, "-overwrite")
+	}
+	if options.LayerName != "" {
+		args = append(args, "-nln", options.LayerName)
+	}
+	if options.SQL != "" {
+		args = append(args, "-sql", options.SQL)
+	}
+	if options.SQLDialect != "" {
+		args = append(args, "-dialect", options.SQLDialect)
+	}
+	if options.Where != "" {
+		args = append(args, "-where", options.Where)
+	}
+	if filter := options.SpatialFilter; filter != nil {
+		args = append(args, "-spat")
+		for _, v := range filter {
+			args = append(args, formatArg(v))
+		}
+		if options.SpatialFilterSRS != "" {
+			args = append(args, "-spat_srs", options.SpatialFilterSRS)
+		}
+	}
+	if options.SrcSRS != "" {
+		args = append(args, "-s_srs", options.SrcSRS)
+	}
+	if options.DstSRS != "" {
+		args = append(args, "-t_srs", options.DstSRS)
+	}
+	if options.GeometryType != "" {
+		args = append(args, "-nlt", options.GeometryType)
+	}
+	if options.Fields != nil {
+		args = append(args, "-select", strings.Join(options.Fields, ","))
+	}
+	if options.FieldMap != nil {
+		fieldMap := make([]string, len(options.FieldMap))
+		for i, index := range options.FieldMap {
+			fieldMap[i] = strconv.Itoa(index)
+		}
+		args = append(args, "-fieldmap", strings.Join(fieldMap, ","))
+	}
+	switch {
+	case options.GroupTransactions < 0:
+		args = append(args, "-gt", "unlimited")
+	case options.GroupTransactions > 0:
+		args = append(args, "-gt", strconv.Itoa(options.GroupTransactions))
+	}
+	args = append(args, options.ExtraArgs...)
+	// Source layers are positional arguments following the switches
+	return append(args, options.Layers...)
+}
+
+// Convert the layers of the source data source to the dst data source,
+// equivalent to ogr2ogr.  The returned data source must be closed to flush
+// it to disk.
+func VectorTranslate(dst string, src DataSource, options VectorTranslateOptions) (DataSource, error) {
+	src.check()
+	cDst := C.CString(dst)
+	defer C.free(unsafe.Pointer(cDst))
+	argv, free := cStringList(options.args())
+	defer free()
+	pf, pa, release := progressProxy(options.Progress, options.ProgressData)
+	defer release()
+
+	srcs := []C.GDALDatasetH{C.GDALDatasetH(src.cval)}
+	var dataset C.GDALDatasetH
+	messages := captureErrors(func() {
+		opts := C.GDALVectorTranslateOptionsNew(argv, nil)
+		if opts == nil {
+			return
+		}
+		defer C.GDALVectorTranslateOptionsFree(opts)
+		C.GDALVectorTranslateOptionsSetProgress(opts, pf, pa)
+		dataset = C.GDALVectorTranslate(cDst, nil, 1, &srcs[0], opts, nil)
+	})
+	// Keep the source from being finalized, closing it, during the call
+	runtime.KeepAlive(src.own)
+	if dataset == nil {
+		return DataSource{}, failure(messages, "Error: ogr2ogr to '%s' failed", dst)
+	}
+	return ownDataSource(C.OGRDataSourceH(dataset)), nil
+}
@@ -0,0 +1,272 @@
+package gdal
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+/* -------------------------------------------------------------------- */
+/*      VRT builder.                                                    */
+/* -------------------------------------------------------------------- */
+
+// Virtual raster assembled from bands of other raster files, serialized to
+// VRT XML or opened directly as a dataset without copying pixels
+type VRTDataset struct {
+	Width, Height int
+	// Geotransform, or zero for none
+	GeoTransform [6]float64
+	// SRS as WKT, or empty for none
+	SRS   string
+	Bands []*VRTBand
+}
+
+// Band of a VRTDataset, composed of its sources in order, later sources
+// overwriting earlier ones where they overlap
+type VRTBand struct {
+	DataType    DataType
+	ColorInterp ColorInterp
+	// Nodata value of the band, or nil for none
+	NoData *float64
+	// Offset and scale converting pixel values to physical values, with a
+	// zero Scale for none
+	Offset, Scale float64
+	// Color table of the band, or nil for none
+	ColorTable color.Palette
+	Sources    []VRTSource
+}
+
+// Kind of a VRT source, setting how pixels are read from it
+type VRTSourceKind int
+
+const (
+	// Copy pixels unchanged
+	VRTSimpleSource = VRTSourceKind(iota)
+	// Apply nodata masking, scaling and lookup tables to pixels
+	VRTComplexSource
+	// Average pixels when reducing resolution, otherwise like a simple
+	// source
+	VRTAveragedSource
+)
+
+// Source of a VRT band, reading a band of a raster file
+type VRTSource struct {
+	Kind     VRTSourceKind
+	Filename string
+	// Resolve Filename relative to the VRT file rather than the working
+	// directory
+	RelativeToVRT bool
+	Band          int
+	// Window read from the source band, and window of the VRT band it is
+	// written to, resampled if the sizes differ; empty windows cover the
+	// whole band
+	SrcWindow, DstWindow image.Rectangle
+	// Source pixel value left out of the VRT band, or nil for none; only
+	// supported by complex sources
+	NoData *float64
+	// Scaling applied as value*ScaleRatio + ScaleOffset, with a zero
+	// ScaleRatio for none; only supported by complex sources
+	ScaleOffset, ScaleRatio float64
+	// Lookup table as increasing input value and output value pairs,
+	// interpolated linearly; only supported by complex sources
+	LUT [][2]float64
+}
+
+// Create a VRT dataset of the given size in pixels, without bands
+func NewVRTDataset(width, height int) *VRTDataset {
+	return &VRTDataset{Width: width, Height: height}
+}
+
+// Add a band of the given data type, returning it for its sources to be
+// added
+func (vrt *VRTDataset) AddBand(dataType DataType) *VRTBand {
+	band := &VRTBand{DataType: dataType}
+	vrt.Bands = append(vrt.Bands, band)
+	return band
+}
+
+// Add a source to the band
+func (band *VRTBand) AddSource(source VRTSource) {
+	band.Sources = append(band.Sources, source)
+}
+
+// XML serialization of a VRT dataset
+type vrtDatasetXML struct {
+	XMLName      xml.Name     `xml:"VRTDataset"`
+	Width        int          `xml:"rasterXSize,attr"`
+	Height       int          `xml:"rasterYSize,attr"`
+	SRS          string       `xml:"SRS,omitempty"`
+	GeoTransform string       `xml:"GeoTransform,omitempty"`
+	Bands        []vrtBandXML `xml:"VRTRasterBand"`
+}
+
+type vrtBandXML struct {
+	DataType    string        `xml:"dataType,attr"`
+	Band        int           `xml:"band,attr"`
+	ColorInterp string        `xml:"ColorInterp,omitempty"`
+	NoData      string        `xml:"NoDataValue,omitempty"`
+	Offset      string        `xml:"Offset,omitempty"`
+	Scale       string        `xml:"Scale,omitempty"`
+	ColorTable  *vrtColorsXML `xml:"ColorTable"`
+	Sources     []vrtSourceXML
+}
+
+type vrtColorsXML struct {
+	Entries []vrtColorXML `xml:"Entry"`
+}
+
+type vrtColorXML struct {
+	C1 uint8 `xml:"c1,attr"`
+	C2 uint8 `xml:"c2,attr"`
+	C3 uint8 `xml:"c3,attr"`
+	C4 uint8 `xml:"c4,attr"`
+}
+
+type vrtSourceXML struct {
+	XMLName     xml.Name
+	Filename    vrtFilenameXML `xml:"SourceFilename"`
+	Band        int            `xml:"SourceBand"`
+	SrcRect     *vrtRectXML    `xml:"SrcRect"`
+	DstRect     *vrtRectXML    `xml:"DstRect"`
+	ScaleOffset string         `xml:"ScaleOffset,omitempty"`
+	ScaleRatio  string         `xml:"ScaleRatio,omitempty"`
+	NoData      string         `xml:"NODATA,omitempty"`
+	LUT         string         `xml:"LUT,omitempty"`
+}
+
+type vrtFilenameXML struct {
+	RelativeToVRT int    `xml:"relativeToVRT,attr"`
+	Filename      string `xml:",chardata"`
+}
+
+type vrtRectXML struct {
+	XOff  int `xml:"xOff,attr"`
+	YOff  int `xml:"yOff,attr"`
+	XSize int `xml:"xSize,attr"`
+	YSize int `xml:"ySize,attr"`
+}
+
+// Convert a window to a rectangle element, or nil for an empty window
+func vrtRect(window image.Rectangle) *vrtRectXML {
+	if window.Empty() {
+		return nil
+	}
+	return &vrtRectXML{window.Min.X, window.Min.Y, window.Dx(), window.Dy()}
+}
+
+// Convert a source to its XML element
+func (source VRTSource) xml() (vrtSourceXML, error) {
+	s := vrtSourceXML{
+		Filename: vrtFilenameXML{Filename: source.Filename},
+		Band:     source.Band,
+		SrcRect:  vrtRect(source.SrcWindow),
+		DstRect:  vrtRect(source.DstWindow),
+	}
+	if source.RelativeToVRT {
+		s.Filename.RelativeToVRT = 1
+	}
+	if source.Band <= 0 {
+		return s, fmt.Errorf("invalid source band %d", source.Band)
+	}
+
+	switch source.Kind {
+	case VRTSimpleSource:
+		s.XMLName.Local = "SimpleSource"
+	case VRTComplexSource:
+		s.XMLName.Local = "ComplexSource"
+	case VRTAveragedSource:
+		s.XMLName.Local = "AveragedSource"
+	default:
+		return s, fmt.Errorf("invalid source kind %d", source.Kind)
+	}
+	if source.Kind != VRTComplexSource {
+		// GDAL silently ignores these elements on other sources
+		if source.NoData != nil || source.ScaleRatio != 0 || source.LUT != nil {
+			return s, fmt.Errorf("nodata, scaling and lookup tables need a complex source")
+		}
+		return s, nil
+	}
+
+	if source.NoData != nil {
+		s.NoData = formatArg(*source.NoData)
+	}
+	if source.ScaleRatio != 0 {
+		s.ScaleOffset = formatArg(source.ScaleOffset)
+		s.ScaleRatio = formatArg(source.ScaleRatio)
+	}
+	if source.LUT != nil {
+		entries := make([]string, len(source.LUT))
+		for i, entry := range source.LUT {
+			entries[i] = formatArg(entry[0]) + ":" + formatArg(entry[1])
+		}
+		s.LUT = strings.Join(entries, ",")
+	}
+	return s, nil
+}
+
+// Serialize the dataset to VRT XML
+func (vrt *VRTDataset) XML() (string, error) {
+	if vrt.Width <= 0 || vrt.Height <= 0 {
+		return "", fmt.Errorf("Error: invalid VRT size %dx%d", vrt.Width, vrt.Height)
+	}
+	doc := vrtDatasetXML{Width: vrt.Width, Height: vrt.Height, SRS: vrt.SRS}
+	if vrt.GeoTransform != [6]float64{} {
+		coefficients := make([]string, len(vrt.GeoTransform))
+		for i, v := range vrt.GeoTransform {
+			coefficients[i] = strconv.FormatFloat(v, 'e', 16, 64)
+		}
+		doc.GeoTransform = strings.Join(coefficients, ", ")
+	}
+
+	for i, band := range vrt.Bands {
+		if band.DataType == Unknown {
+			return "", fmt.Errorf("Error: band %d has no data type", i+1)
+		}
+		b := vrtBandXML{DataType: band.DataType.Name(), Band: i + 1}
+		if band.ColorInterp != CI_Undefined {
+			b.ColorInterp = band.ColorInterp.Name()
+		}
+		if band.NoData != nil {
+			b.NoData = formatArg(*band.NoData)
+		}
+		if band.Scale != 0 {
+			b.Offset = formatArg(band.Offset)
+			b.Scale = formatArg(band.Scale)
+		}
+		if band.ColorTable != nil {
+			b.ColorTable = new(vrtColorsXML)
+			for _, c := range band.ColorTable {
+				nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+				b.ColorTable.Entries = append(b.ColorTable.Entries, vrtColorXML{nrgba.R, nrgba.G, nrgba.B, nrgba.A})
+			}
+		}
+		for j, source := range band.Sources {
+			s, err := source.xml()
+			if err != nil {
+				return "", fmt.Errorf("Error: band %d source %d: %w", i+1, j+1, err)
+			}
+			b.Sources = append(b.Sources, s)
+		}
+		doc.Bands = append(doc.Bands, b)
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Open the dataset through the VRT driver, reading pixels from the
+// sources on demand.  Sources relative to the VRT are resolved against the
+// working directory.
+func (vrt *VRTDataset) Open() (Dataset, error) {
+	doc, err := vrt.XML()
+	if err != nil {
+		return Dataset{}, err
+	}
+	return OpenEx(doc, OpenOptions{Raster: true, AllowedDrivers: []string{"VRT"}})
+}